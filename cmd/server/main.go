@@ -54,8 +54,17 @@ func main() {
 	}
 	slog.Info("Migrations completed successfully")
 
+	// The HTTP handlers and the background jobs share the services and their reviewer selection state
+	services := router.NewServices(db, cfg.Selection)
+
+	// Start the periodic checks and the job workers
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	waitScheduler := startScheduler(jobsCtx, services, cfg.Jobs)
+	waitJobs := startWorkers(jobsCtx, services.Job, cfg.Jobs)
+
 	// Setup router
-	router := router.SetupRouter(db)
+	router := router.SetupRouter(services, cfg.Admin)
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -89,5 +98,10 @@ func main() {
 		slog.Error("Server forced to shutdown", "error", err)
 	}
 
+	// Workers finish their current item and put unfinished jobs back in the queue
+	stopJobs()
+	waitJobs()
+	waitScheduler()
+
 	slog.Info("Server exited")
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avito-tech-internship/internal/config"
+	"avito-tech-internship/internal/router"
+)
+
+// startScheduler launches the periodic absence, SLA and archive checks, which run until ctx is cancelled.
+// The returned function waits for a check in progress to finish
+func startScheduler(ctx context.Context, services *router.Services, cfg config.JobsConfig) (wait func()) {
+	var checks sync.WaitGroup
+	schedule := func(interval time.Duration, check func()) {
+		checks.Add(1)
+		go func() {
+			defer checks.Done()
+			runPeriodically(ctx, interval, check)
+		}()
+	}
+
+	schedule(cfg.AbsenceCheckInterval, func() {
+		processed, err := services.Absence.ReassignStartedAbsences()
+		if err != nil {
+			slog.Error("Failed to reassign reviews of absent users", "error", err)
+		}
+		if processed > 0 {
+			slog.Info("Reassigned reviews of absent users", "absences", processed)
+		}
+	})
+
+	schedule(cfg.SLACheckInterval, func() {
+		overdue, reassigned, err := services.SLA.EscalateOverdueReviews()
+		if err != nil {
+			slog.Error("Failed to escalate overdue reviews", "error", err)
+		}
+		if overdue > 0 || reassigned > 0 {
+			slog.Info("Escalated overdue reviews", "overdue", overdue, "reassigned", reassigned)
+		}
+	})
+
+	if cfg.ArchiveAfterDays > 0 {
+		retention := time.Duration(cfg.ArchiveAfterDays) * 24 * time.Hour
+		schedule(cfg.ArchiveCheckInterval, func() {
+			archived, err := services.PullRequest.ArchiveMergedPRs(retention)
+			if err != nil {
+				slog.Error("Failed to archive merged PRs", "error", err)
+			}
+			if archived > 0 {
+				slog.Info("Archived merged PRs", "archived", archived)
+			}
+		})
+	}
+
+	return checks.Wait
+}
+
+// runPeriodically calls job every interval until ctx is cancelled
+func runPeriodically(ctx context.Context, interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job()
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"avito-tech-internship/internal/config"
+	"avito-tech-internship/internal/service"
+)
+
+// startWorkers launches the pool of workers processing asynchronous jobs, which run until ctx is cancelled.
+// The returned function waits for the workers to stop
+func startWorkers(ctx context.Context, jobService *service.JobService, cfg config.JobsConfig) (wait func()) {
+	var workers sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			runWorker(ctx, jobService, cfg.PollInterval, cfg.Lease)
+		}()
+	}
+
+	return workers.Wait
+}
+
+// runWorker processes queued jobs one at a time, waiting pollInterval whenever the queue is empty,
+// until ctx is cancelled
+func runWorker(ctx context.Context, jobService *service.JobService, pollInterval, lease time.Duration) {
+	for ctx.Err() == nil {
+		ran, err := jobService.RunNext(ctx, lease)
+		if err != nil {
+			slog.Error("Failed to run job", "error", err)
+		}
+		if ran && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -3,17 +3,53 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Config struct {
-	Server ServerConfig
-	DB     DBConfig
+	Server    ServerConfig
+	DB        DBConfig
+	Jobs      JobsConfig
+	Selection SelectionConfig
+	Admin     AdminConfig
 }
 
 type ServerConfig struct {
 	Port string
 }
 
+// JobsConfig configures background jobs
+type JobsConfig struct {
+	// AbsenceCheckInterval is how often started absences are checked for reviews to reassign
+	AbsenceCheckInterval time.Duration
+	// SLACheckInterval is how often review assignments are checked against team review SLAs
+	SLACheckInterval time.Duration
+	// ArchiveCheckInterval is how often merged PRs are checked for archiving
+	ArchiveCheckInterval time.Duration
+	// ArchiveAfterDays is how many days after merge a PR is archived (0 = never)
+	ArchiveAfterDays int
+	// Workers is how many asynchronous jobs are processed at once
+	Workers int
+	// PollInterval is how often an idle worker checks for queued jobs
+	PollInterval time.Duration
+	// Lease is how long a job stays claimed by a worker that stopped reporting progress
+	// before another worker resumes it
+	Lease time.Duration
+}
+
+// SelectionConfig configures reviewer selection
+type SelectionConfig struct {
+	// Seed makes the sequence of reviewer assignments reproducible (nil = seeded from time)
+	Seed *int64
+}
+
+// AdminConfig configures access to admin-only endpoints
+type AdminConfig struct {
+	// Token must be sent in the X-Admin-Token header (empty = admin-only endpoints are disabled)
+	Token string
+}
+
 type DBConfig struct {
 	Host     string
 	Port     string
@@ -28,6 +64,9 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
 		},
+		Admin: AdminConfig{
+			Token: os.Getenv("ADMIN_TOKEN"),
+		},
 		DB: DBConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
 			Port:     getEnv("DB_PORT", "5432"),
@@ -38,6 +77,56 @@ func Load() (*Config, error) {
 		},
 	}
 
+	absenceCheckInterval, err := time.ParseDuration(getEnv("ABSENCE_CHECK_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ABSENCE_CHECK_INTERVAL: %w", err)
+	}
+	cfg.Jobs.AbsenceCheckInterval = absenceCheckInterval
+
+	slaCheckInterval, err := time.ParseDuration(getEnv("SLA_CHECK_INTERVAL", "5m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLA_CHECK_INTERVAL: %w", err)
+	}
+	cfg.Jobs.SLACheckInterval = slaCheckInterval
+
+	archiveCheckInterval, err := time.ParseDuration(getEnv("ARCHIVE_CHECK_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARCHIVE_CHECK_INTERVAL: %w", err)
+	}
+	cfg.Jobs.ArchiveCheckInterval = archiveCheckInterval
+
+	archiveAfterDays, err := strconv.Atoi(getEnv("ARCHIVE_AFTER_DAYS", "90"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ARCHIVE_AFTER_DAYS: %w", err)
+	}
+	cfg.Jobs.ArchiveAfterDays = archiveAfterDays
+
+	workers, err := strconv.Atoi(getEnv("JOB_WORKERS", "2"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_WORKERS: %w", err)
+	}
+	cfg.Jobs.Workers = workers
+
+	pollInterval, err := time.ParseDuration(getEnv("JOB_POLL_INTERVAL", "1s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_POLL_INTERVAL: %w", err)
+	}
+	cfg.Jobs.PollInterval = pollInterval
+
+	lease, err := time.ParseDuration(getEnv("JOB_LEASE", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JOB_LEASE: %w", err)
+	}
+	cfg.Jobs.Lease = lease
+
+	if seed := os.Getenv("SELECTION_SEED"); seed != "" {
+		value, err := strconv.ParseInt(seed, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SELECTION_SEED: %w", err)
+		}
+		cfg.Selection.Seed = &value
+	}
+
 	if err := cfg.validate(); err != nil {
 		return nil, err
 	}
@@ -55,6 +144,27 @@ func (c *Config) validate() error {
 	if c.DB.Name == "" {
 		return fmt.Errorf("DB_NAME is required")
 	}
+	if c.Jobs.AbsenceCheckInterval <= 0 {
+		return fmt.Errorf("ABSENCE_CHECK_INTERVAL must be positive")
+	}
+	if c.Jobs.SLACheckInterval <= 0 {
+		return fmt.Errorf("SLA_CHECK_INTERVAL must be positive")
+	}
+	if c.Jobs.ArchiveCheckInterval <= 0 {
+		return fmt.Errorf("ARCHIVE_CHECK_INTERVAL must be positive")
+	}
+	if c.Jobs.ArchiveAfterDays < 0 {
+		return fmt.Errorf("ARCHIVE_AFTER_DAYS must not be negative")
+	}
+	if c.Jobs.Workers <= 0 {
+		return fmt.Errorf("JOB_WORKERS must be positive")
+	}
+	if c.Jobs.PollInterval <= 0 {
+		return fmt.Errorf("JOB_POLL_INTERVAL must be positive")
+	}
+	if c.Jobs.Lease <= 0 {
+		return fmt.Errorf("JOB_LEASE must be positive")
+	}
 	return nil
 }
 
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// Absence is an out-of-office period of a user; the user is not picked as a reviewer
+// between StartsAt (inclusive) and EndsAt (exclusive)
+type Absence struct {
+	AbsenceID int64     `json:"absence_id"`
+	UserID    string    `json:"user_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason,omitempty"`
+	// ReassignReviews moves the user's open reviews to other reviewers once the absence starts
+	ReassignReviews bool       `json:"reassign_reviews"`
+	ReassignedAt    *time.Time `json:"reassigned_at,omitempty"`
+	CancelledAt     *time.Time `json:"cancelled_at,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+}
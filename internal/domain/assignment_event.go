@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// AssignmentEventType tells how a reviewer assignment changed
+type AssignmentEventType string
+
+const (
+	AssignmentEventAssign   AssignmentEventType = "assign"
+	AssignmentEventUnassign AssignmentEventType = "unassign"
+	AssignmentEventReassign AssignmentEventType = "reassign"
+	// AssignmentEventBulkDeactivate is a reassignment caused by a bulk deactivation of reviewers
+	AssignmentEventBulkDeactivate AssignmentEventType = "bulk_deactivate"
+	// AssignmentEventForceMerge is a merge that bypassed the approval gate; it changes no reviewer
+	AssignmentEventForceMerge AssignmentEventType = "force_merge"
+)
+
+// AssignmentEvent is an append-only record of a reviewer assignment change on a PR
+type AssignmentEvent struct {
+	EventID       int64               `json:"event_id"`
+	PullRequestID string              `json:"pull_request_id"`
+	Type          AssignmentEventType `json:"type"`
+	// UserID is the reviewer assigned (assign), unassigned (unassign) or replaced (reassign, bulk_deactivate);
+	// empty for force_merge
+	UserID string `json:"user_id,omitempty"`
+	// ReplacementID is the reviewer who took the review over (reassign, bulk_deactivate)
+	ReplacementID string `json:"replacement_id,omitempty"`
+	Actor         string `json:"actor"`
+	Reason        string `json:"reason,omitempty"`
+	// Strategy is how the newly assigned reviewer was picked (empty for manual assignments)
+	Strategy  ReviewerStrategy `json:"strategy,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// AssignmentChange tells who changes reviewer assignments and why; it is recorded in assignment events
+type AssignmentChange struct {
+	// Type is recorded for a replacement of a reviewer (reassign by default);
+	// added and removed reviewers are recorded as assign and unassign
+	Type   AssignmentEventType
+	Actor  string
+	Reason string
+}
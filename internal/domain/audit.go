@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// AuditRecord is an entry of the audit log of mutating API calls
+type AuditRecord struct {
+	RecordID int64  `json:"record_id"`
+	Actor    string `json:"actor"`
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	// RequestSummary is the request body, compacted and truncated
+	RequestSummary string    `json:"request_summary"`
+	ResultCode     int       `json:"result_code"`
+	RequestID      string    `json:"request_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// AuditQuery filters the audit log; zero fields match any record. Time bounds are
+// inclusive below and exclusive above
+type AuditQuery struct {
+	Actor      string
+	Endpoint   string
+	ResultCode int
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditPage is one page of the audit log, newest records first; NextCursor is empty on the last page
+type AuditPage struct {
+	Records    []*AuditRecord `json:"records"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
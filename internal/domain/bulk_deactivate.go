@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// BulkDeactivateResult reports what a bulk deactivation of team members changed, or for a dry run
+// what it would change
+type BulkDeactivateResult struct {
+	// PlanID identifies the stored plan of a dry run, which can then be executed as previewed
+	PlanID           int64    `json:"plan_id,omitempty"`
+	TeamName         string   `json:"team_name"`
+	DeactivatedUsers []string `json:"deactivated_users"`
+	// AffectedPRs are the OPEN PRs where a deactivated user was a reviewer
+	AffectedPRs   []string              `json:"affected_prs"`
+	Replacements  []ReviewerReplacement `json:"replacements"`
+	UnfilledSlots []UnfilledSlot        `json:"unfilled_slots"`
+}
+
+// ReviewerReplacement is a deactivated reviewer replaced on a PR
+type ReviewerReplacement struct {
+	PullRequestID string `json:"pull_request_id"`
+	OldUserID     string `json:"old_user_id"`
+	NewUserID     string `json:"new_user_id"`
+}
+
+// UnfilledSlot is a deactivated reviewer nobody could replace; they are removed from the PR
+type UnfilledSlot struct {
+	PullRequestID string `json:"pull_request_id"`
+	UserID        string `json:"user_id"`
+	// Reason tells why no replacement was found
+	Reason string `json:"reason"`
+}
+
+// BulkDeactivatePlan is a bulk deactivation computed by a dry run; executing it applies exactly its steps
+type BulkDeactivatePlan struct {
+	PlanID     int64
+	TeamName   string
+	UserIDs    []string
+	Steps      []DeactivationStep
+	CreatedAt  time.Time
+	ExecutedAt *time.Time
+}
+
+// DeactivationStep is the change of one review of a deactivated reviewer
+type DeactivationStep struct {
+	PullRequestID string
+	OldUserID     string
+	// Replacement takes the review over; nil when nobody can
+	Replacement *ReviewerSelection
+	// IsFallback marks a replacement from another team
+	IsFallback bool
+	// Reason tells why nobody can take the review over
+	Reason string
+}
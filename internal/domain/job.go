@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JobType is the kind of bulk operation a job runs
+type JobType string
+
+const (
+	JobTypeBulkDeactivate JobType = "bulk_deactivate"
+	JobTypeTeamImport     JobType = "team_import"
+	JobTypeMassReassign   JobType = "mass_reassign"
+)
+
+// JobStatus is the state of a job
+type JobStatus string
+
+const (
+	JobStatusQueued  JobStatus = "queued"
+	JobStatusRunning JobStatus = "running"
+	// JobStatusCompleted means every item was processed; some of them may have failed
+	JobStatusCompleted JobStatus = "completed"
+	// JobStatusFailed means the job could not be processed at all
+	JobStatusFailed JobStatus = "failed"
+)
+
+// Job is a bulk operation run asynchronously by the worker pool, item by item
+type Job struct {
+	JobID  int64     `json:"job_id"`
+	Type   JobType   `json:"type"`
+	Status JobStatus `json:"status"`
+	Actor  string    `json:"actor"`
+	// Payload is the request of the job, decoded by the worker according to its type
+	Payload        json.RawMessage `json:"-"`
+	TotalItems     int             `json:"total_items"`
+	ProcessedItems int             `json:"processed_items"`
+	FailedItems    int             `json:"failed_items"`
+	Errors         []JobItemError  `json:"errors"`
+	// Error explains why the job failed as a whole
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// JobItemError is the error of one failed item of a job
+type JobItemError struct {
+	Item    string `json:"item"`
+	Message string `json:"message"`
+}
@@ -6,25 +6,152 @@ import "time"
 type PRStatus string
 
 const (
+	// PRStatusDraft is a work in progress PR; reviewers are assigned once it is marked ready
+	PRStatusDraft  PRStatus = "DRAFT"
 	PRStatusOpen   PRStatus = "OPEN"
 	PRStatusMerged PRStatus = "MERGED"
+	// PRStatusClosed is a PR abandoned without merge; its reviewers are released
+	PRStatusClosed PRStatus = "CLOSED"
 )
 
+// IsValid reports whether the status is known
+func (s PRStatus) IsValid() bool {
+	switch s {
+	case PRStatusDraft, PRStatusOpen, PRStatusMerged, PRStatusClosed:
+		return true
+	}
+	return false
+}
+
+// PRPriority tells how urgently a PR needs review
+type PRPriority string
+
+const (
+	PRPriorityLow    PRPriority = "low"
+	PRPriorityNormal PRPriority = "normal"
+	// PRPriorityUrgent PRs go to the least loaded reviewers regardless of the team's strategy
+	PRPriorityUrgent PRPriority = "urgent"
+)
+
+// IsValid reports whether the priority is known
+func (p PRPriority) IsValid() bool {
+	switch p {
+	case PRPriorityLow, PRPriorityNormal, PRPriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// ReviewVerdict is the decision a reviewer submitted on a PR
+type ReviewVerdict string
+
+const (
+	ReviewVerdictApproved         ReviewVerdict = "APPROVED"
+	ReviewVerdictChangesRequested ReviewVerdict = "CHANGES_REQUESTED"
+	ReviewVerdictCommented        ReviewVerdict = "COMMENTED"
+)
+
+// IsValid reports whether the verdict is known
+func (v ReviewVerdict) IsValid() bool {
+	switch v {
+	case ReviewVerdictApproved, ReviewVerdictChangesRequested, ReviewVerdictCommented:
+		return true
+	}
+	return false
+}
+
 // PullRequest represents a Pull Request
 type PullRequest struct {
 	PullRequestID     string     `json:"pull_request_id"`
 	PullRequestName   string     `json:"pull_request_name"`
 	AuthorID          string     `json:"author_id"`
 	Status            PRStatus   `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"` // user_id list (0..2)
-	CreatedAt         *time.Time `json:"createdAt,omitempty"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	AssignedReviewers []string   `json:"assigned_reviewers"` // user_id list (0..team max_reviewer_count)
+	Description       string     `json:"description,omitempty"`
+	Labels            []string   `json:"labels,omitempty"`
+	Priority          PRPriority `json:"priority"`
+	// Size is the number of changed lines (0 = unknown)
+	Size int `json:"size,omitempty"`
+	// DependsOn lists PRs that must be merged before this one
+	DependsOn []string `json:"depends_on,omitempty"`
+	// FallbackReviewers lists assigned reviewers taken from other teams because the author's team
+	// could not fill all slots
+	FallbackReviewers []string `json:"fallback_reviewers,omitempty"`
+	// RequiredTags should each be covered by at least one assigned reviewer
+	RequiredTags []string `json:"required_tags,omitempty"`
+	// Selections record how each assigned reviewer was picked
+	Selections []ReviewerSelection `json:"selections,omitempty"`
+	// Reviews hold the latest verdict of each assigned reviewer who submitted one
+	Reviews []ReviewerState `json:"reviews,omitempty"`
+	// ForceMerged is set when the PR was merged bypassing the approval gate
+	ForceMerged bool       `json:"force_merged,omitempty"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	MergedAt    *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt    *time.Time `json:"closedAt,omitempty"`
+	// ArchivedAt is set once the merged PR is archived and hidden from reviewer lists and stats
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
+}
+
+// ReviewerSelection records the strategy and random seed a reviewer was picked with,
+// so that the pick can be replayed against the same candidates
+type ReviewerSelection struct {
+	UserID   string           `json:"user_id"`
+	Strategy ReviewerStrategy `json:"strategy"`
+	Seed     int64            `json:"seed"`
+}
+
+// ReviewerState is the latest verdict of an assigned reviewer
+type ReviewerState struct {
+	UserID      string        `json:"user_id"`
+	Verdict     ReviewVerdict `json:"verdict"`
+	SubmittedAt time.Time     `json:"submitted_at"`
 }
 
 // PullRequestShort represents a shortened version of PR (for list responses)
 type PullRequestShort struct {
-	PullRequestID   string   `json:"pull_request_id"`
-	PullRequestName string   `json:"pull_request_name"`
-	AuthorID        string   `json:"author_id"`
-	Status          PRStatus `json:"status"`
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          PRStatus   `json:"status"`
+	Labels          []string   `json:"labels,omitempty"`
+	Priority        PRPriority `json:"priority"`
+	Size            int        `json:"size,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+}
+
+// PRQuery selects PRs of a listing; zero fields match any PR
+type PRQuery struct {
+	Status     PRStatus
+	AuthorID   string
+	ReviewerID string
+	// TeamName matches PRs whose author is a member of the team
+	TeamName string
+	Label    string
+	Priority PRPriority
+	// CreatedFrom, CreatedTo, MergedFrom and MergedTo bound the time range: From is inclusive, To is exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	// Ascending lists the oldest PRs first (default: newest first)
+	Ascending bool
+}
+
+// PRPage is a page of a PR listing ordered by creation time
+type PRPage struct {
+	PullRequests []*PullRequestShort `json:"pull_requests"`
+	// NextCursor fetches the next page (empty on the last page)
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// OverdueAssignment is a review assignment on an OPEN PR that exceeded the review SLA of the author's team
+type OverdueAssignment struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	UserID          string    `json:"user_id"`
+	TeamName        string    `json:"team_name"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	OverdueAt       time.Time `json:"overdue_at"`
 }
@@ -7,6 +7,8 @@ type Stats struct {
 	AverageReviewersPerPR float64               `json:"average_reviewers_per_pr"`
 	AssignmentsByUser     []UserAssignmentStats `json:"assignments_by_user"`
 	ReviewersPerPR        []PRReviewerStats     `json:"reviewers_per_pr"`
+	// PairingMatrix maps author_id -> reviewer_id -> number of the author's PRs the reviewer was assigned to
+	PairingMatrix map[string]map[string]int `json:"pairing_matrix"`
 }
 
 // UserAssignmentStats represents assignment statistics for a user
@@ -14,6 +16,11 @@ type UserAssignmentStats struct {
 	UserID          string `json:"user_id"`
 	Username        string `json:"username"`
 	AssignmentCount int    `json:"assignment_count"`
+	// DeclineCount is the number of reviews the user declined
+	DeclineCount int `json:"decline_count"`
+	// ReassignedAwayCount is the number of reviews reassigned from the user to someone else,
+	// including declined ones that got a replacement
+	ReassignedAwayCount int `json:"reassigned_away_count"`
 }
 
 // PRReviewerStats represents reviewer count for a PR
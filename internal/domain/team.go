@@ -1,7 +1,77 @@
 package domain
 
+// ReviewerStrategy represents the algorithm used to pick reviewers for a team
+type ReviewerStrategy string
+
+const (
+	ReviewerStrategyRandom      ReviewerStrategy = "random"
+	ReviewerStrategyRoundRobin  ReviewerStrategy = "round_robin"
+	ReviewerStrategyLeastLoaded ReviewerStrategy = "least_loaded"
+	ReviewerStrategyWeighted    ReviewerStrategy = "weighted"
+)
+
+// IsValid reports whether the strategy is one of the built-in strategies
+func (s ReviewerStrategy) IsValid() bool {
+	switch s {
+	case ReviewerStrategyRandom, ReviewerStrategyRoundRobin, ReviewerStrategyLeastLoaded, ReviewerStrategyWeighted:
+		return true
+	}
+	return false
+}
+
+// CapacityOverflow defines what happens when all candidate reviewers are at capacity
+type CapacityOverflow string
+
+const (
+	// CapacityOverflowAssignFewer assigns only the reviewers that still have capacity
+	CapacityOverflowAssignFewer CapacityOverflow = "assign_fewer"
+	// CapacityOverflowTeam fills missing slots from the configured overflow team
+	CapacityOverflowTeam CapacityOverflow = "overflow_team"
+	// CapacityOverflowReject fails the operation with a capacity error
+	CapacityOverflowReject CapacityOverflow = "reject"
+)
+
+// IsValid reports whether the overflow policy is known
+func (o CapacityOverflow) IsValid() bool {
+	switch o {
+	case CapacityOverflowAssignFewer, CapacityOverflowTeam, CapacityOverflowReject:
+		return true
+	}
+	return false
+}
+
 // Team represents a team with its members
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName         string           `json:"team_name"`
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	Members          []TeamMember     `json:"members"`
+}
+
+// TeamSettings holds per-team reviewer assignment configuration
+type TeamSettings struct {
+	TeamName         string           `json:"team_name"`
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy"`
+	// DefaultMaxOpenReviews applies to members without their own limit (nil = unlimited)
+	DefaultMaxOpenReviews *int             `json:"default_max_open_reviews"`
+	CapacityOverflow      CapacityOverflow `json:"capacity_overflow"`
+	OverflowTeam          string           `json:"overflow_team,omitempty"`
+	// ReviewerCount is the number of reviewers assigned to a new PR; a PR may request
+	// a different count within [MinReviewerCount, MaxReviewerCount]
+	ReviewerCount    int `json:"reviewer_count"`
+	MinReviewerCount int `json:"min_reviewer_count"`
+	MaxReviewerCount int `json:"max_reviewer_count"`
+	// FallbackTeams are asked in order to fill reviewer slots the team cannot fill itself
+	FallbackTeams []string `json:"fallback_teams"`
+	// AffinityWindowDays down-weights candidates who reviewed the same author within
+	// that many days (0 disables affinity decay)
+	AffinityWindowDays int `json:"affinity_window_days"`
+	// RequiredApprovals is the number of APPROVED verdicts a PR of the team needs to be merged
+	RequiredApprovals int `json:"required_approvals"`
+	// ReviewSLAHours is how long an assigned reviewer has to submit a verdict (0 disables the SLA)
+	ReviewSLAHours int `json:"review_sla_hours"`
+	// SLAReassign reassigns overdue reviews instead of only marking them
+	SLAReassign bool `json:"sla_reassign"`
+	// LargePRLines is the size from which a PR gets one more reviewer, within MaxReviewerCount
+	// (0 disables the extra reviewer)
+	LargePRLines int `json:"large_pr_lines"`
 }
@@ -6,11 +6,19 @@ type User struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// MaxOpenReviews limits concurrently open reviews (nil = team default)
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// Tags are the reviewer's skills (e.g. go, sql, frontend)
+	Tags []string `json:"tags,omitempty"`
+	// IsAbsent is set while one of the user's absences is in effect
+	IsAbsent bool `json:"is_absent,omitempty"`
 }
 
 // TeamMember represents a member of a team (used in Team response)
 type TeamMember struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	IsActive       bool     `json:"is_active"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
 }
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/service"
+)
+
+type AbsenceHandler struct {
+	absenceService *service.AbsenceService
+}
+
+func NewAbsenceHandler(absenceService *service.AbsenceService) *AbsenceHandler {
+	return &AbsenceHandler{absenceService: absenceService}
+}
+
+// CreateAbsence handles POST /users/absences/create
+func (h *AbsenceHandler) CreateAbsence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID          string    `json:"user_id"`
+		StartsAt        time.Time `json:"starts_at"`
+		EndsAt          time.Time `json:"ends_at"`
+		Reason          string    `json:"reason"`
+		ReassignReviews bool      `json:"reassign_reviews"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	absence := &domain.Absence{
+		UserID:          req.UserID,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		Reason:          req.Reason,
+		ReassignReviews: req.ReassignReviews,
+	}
+
+	if err := h.absenceService.CreateAbsence(absence); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	response := map[string]*domain.Absence{
+		"absence": absence,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// ListAbsences handles GET /users/absences?user_id=...&include_past=true
+func (h *AbsenceHandler) ListAbsences(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, ErrorCodeNotFound, "user_id parameter is required", http.StatusBadRequest)
+		return
+	}
+	includePast := r.URL.Query().Get("include_past") == "true"
+
+	absences, err := h.absenceService.ListAbsences(userID, includePast)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"user_id":  userID,
+		"absences": absences,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// CancelAbsence handles POST /users/absences/cancel
+func (h *AbsenceHandler) CancelAbsence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		AbsenceID int64 `json:"absence_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	absence, err := h.absenceService.CancelAbsence(req.AbsenceID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.Absence{
+		"absence": absence,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ActorHeader names the user or system making the request; it is recorded in the assignment history
+const ActorHeader = "X-Actor"
+
+// anonymousActor is recorded when a request does not name its actor
+const anonymousActor = "anonymous"
+
+// ActorFrom returns the actor named by the request, or anonymous when it names none
+func ActorFrom(r *http.Request) string {
+	actor := strings.TrimSpace(r.Header.Get(ActorHeader))
+	if actor == "" {
+		return anonymousActor
+	}
+	return actor
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// AdminTokenHeader carries the admin token of requests to admin-only endpoints
+const AdminTokenHeader = "X-Admin-Token"
+
+// RequireAdmin rejects requests that do not carry the admin token; with an empty token
+// admin-only endpoints are disabled
+func RequireAdmin(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAdmin(r, token) {
+				writeError(w, ErrorCodeForbidden, "admin token required", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// isAdmin reports whether the request carries the admin token; nobody is an admin with an empty token
+func isAdmin(r *http.Request, token string) bool {
+	provided := r.Header.Get(AdminTokenHeader)
+	return token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/service"
+)
+
+type AuditHandler struct {
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// ListRecords handles GET /admin/audit?actor=...&endpoint=...&result_code=...&from=...&to=...&cursor=...&limit=...
+func (h *AuditHandler) ListRecords(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	query := domain.AuditQuery{
+		Actor:    params.Get("actor"),
+		Endpoint: params.Get("endpoint"),
+	}
+
+	if raw := params.Get("result_code"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeError(w, ErrorCodeNotFound, "result_code must be an integer", http.StatusBadRequest)
+			return
+		}
+		query.ResultCode = parsed
+	}
+
+	from, err := parseTimeParam(params.Get("from"))
+	if err != nil {
+		writeError(w, ErrorCodeNotFound, "from must be an RFC 3339 time or a YYYY-MM-DD date", http.StatusBadRequest)
+		return
+	}
+	query.From = from
+
+	to, err := parseTimeParam(params.Get("to"))
+	if err != nil {
+		writeError(w, ErrorCodeNotFound, "to must be an RFC 3339 time or a YYYY-MM-DD date", http.StatusBadRequest)
+		return
+	}
+	query.To = to
+
+	limit := 0
+	if raw := params.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeError(w, ErrorCodeNotFound, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.auditService.ListRecords(query, params.Get("cursor"), limit)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
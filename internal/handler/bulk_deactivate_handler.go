@@ -11,13 +11,21 @@ import (
 
 type BulkDeactivateHandler struct {
 	bulkDeactivateService *service.BulkDeactivateService
+	jobService            *service.JobService
 }
 
-func NewBulkDeactivateHandler(bulkDeactivateService *service.BulkDeactivateService) *BulkDeactivateHandler {
-	return &BulkDeactivateHandler{bulkDeactivateService: bulkDeactivateService}
+func NewBulkDeactivateHandler(
+	bulkDeactivateService *service.BulkDeactivateService,
+	jobService *service.JobService,
+) *BulkDeactivateHandler {
+	return &BulkDeactivateHandler{
+		bulkDeactivateService: bulkDeactivateService,
+		jobService:            jobService,
+	}
 }
 
-// BulkDeactivate handles POST /users/bulkDeactivate
+// BulkDeactivate handles POST /users/bulkDeactivate. A dry run is answered right away; a deactivation
+// may touch many PRs, so it is queued as a bulk_deactivate job instead of running under the request timeout
 func (h *BulkDeactivateHandler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
@@ -27,6 +35,10 @@ func (h *BulkDeactivateHandler) BulkDeactivate(w http.ResponseWriter, r *http.Re
 	var req struct {
 		TeamName string   `json:"team_name"`
 		UserIDs  []string `json:"user_ids"`
+		// DryRun computes and stores the plan without changing anything
+		DryRun bool `json:"dry_run"`
+		// PlanID executes a plan stored by a dry run instead of computing a new one
+		PlanID int64 `json:"plan_id"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -34,36 +46,70 @@ func (h *BulkDeactivateHandler) BulkDeactivate(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if req.TeamName == "" {
-		writeError(w, ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+	if req.PlanID != 0 && req.DryRun {
+		writeError(w, ErrorCodeNotFound, "plan_id cannot be combined with dry_run", http.StatusBadRequest)
 		return
 	}
 
-	if len(req.UserIDs) == 0 {
-		writeError(w, ErrorCodeNotFound, "user_ids is required", http.StatusBadRequest)
+	if req.PlanID == 0 {
+		if req.TeamName == "" {
+			writeError(w, ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+			return
+		}
+
+		if len(req.UserIDs) == 0 {
+			writeError(w, ErrorCodeNotFound, "user_ids is required", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !req.DryRun {
+		job, err := h.jobService.EnqueueBulkDeactivate([]service.BulkDeactivateJobItem{{
+			TeamName: req.TeamName,
+			UserIDs:  req.UserIDs,
+			PlanID:   req.PlanID,
+		}}, ActorFrom(r))
+		if err != nil {
+			handleServiceError(w, err)
+			return
+		}
+
+		slog.Info("Bulk deactivation queued",
+			"job_id", job.JobID, "team", req.TeamName, "users", req.UserIDs, "plan_id", req.PlanID)
+		writeJob(w, http.StatusAccepted, job)
 		return
 	}
 
 	startTime := time.Now()
-	if err := h.bulkDeactivateService.BulkDeactivate(req.TeamName, req.UserIDs); err != nil {
-		slog.Error("Failed to bulk deactivate users", "error", err, "team", req.TeamName, "users", req.UserIDs)
+	result, err := h.bulkDeactivateService.PlanBulkDeactivate(req.TeamName, req.UserIDs)
+	if err != nil {
+		slog.Error("Failed to plan bulk deactivation",
+			"error", err, "team", req.TeamName, "users", req.UserIDs)
 		handleServiceError(w, err)
 		return
 	}
 
 	duration := time.Since(startTime)
-	slog.Info("Bulk deactivation completed",
-		"team", req.TeamName,
-		"users_count", len(req.UserIDs),
+	slog.Info("Bulk deactivation planned",
+		"team", result.TeamName,
+		"users_count", len(result.DeactivatedUsers),
+		"plan_id", result.PlanID,
+		"replaced_count", len(result.Replacements),
+		"unfilled_count", len(result.UnfilledSlots),
 		"duration_ms", duration.Milliseconds())
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
 	response := map[string]interface{}{
-		"deactivated_users": req.UserIDs,
-		"team_name":         req.TeamName,
+		"deactivated_users": result.DeactivatedUsers,
+		"team_name":         result.TeamName,
+		"affected_prs":      result.AffectedPRs,
+		"replacements":      result.Replacements,
+		"unfilled_slots":    result.UnfilledSlots,
 		"duration_ms":       duration.Milliseconds(),
+		"dry_run":           true,
+		"plan_id":           result.PlanID,
 	}
 
 	if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -18,6 +18,17 @@ const (
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	ErrorCodeNotApproved ErrorCode = "NOT_APPROVED"
+
+	ErrorCodeCapacityExhausted   ErrorCode = "CAPACITY_EXHAUSTED"
+	ErrorCodeInvalidTransition   ErrorCode = "INVALID_TRANSITION"
+	ErrorCodePRNotOpen           ErrorCode = "PR_NOT_OPEN"
+	ErrorCodeForbidden           ErrorCode = "FORBIDDEN"
+	ErrorCodeDependencyNotMerged ErrorCode = "DEPENDENCY_NOT_MERGED"
+	ErrorCodePlanExecuted        ErrorCode = "PLAN_EXECUTED"
+	ErrorCodePlanStale           ErrorCode = "PLAN_STALE"
+	ErrorCodeReviewerLimit       ErrorCode = "REVIEWER_LIMIT_REACHED"
+	ErrorCodeHasDependents       ErrorCode = "HAS_DEPENDENTS"
 )
 
 // ErrorResponse represents error response structure
@@ -47,10 +58,54 @@ func handleServiceError(w http.ResponseWriter, err error) {
 	switch err {
 	case service.ErrTeamExists:
 		writeError(w, ErrorCodeTeamExists, "team_name already exists", http.StatusBadRequest)
+	case service.ErrInvalidStrategy:
+		writeError(w, ErrorCodeNotFound, "unknown reviewer_strategy", http.StatusBadRequest)
+	case service.ErrInvalidSettings:
+		writeError(w, ErrorCodeNotFound, "invalid team settings", http.StatusBadRequest)
+	case service.ErrInvalidOwners:
+		writeError(w, ErrorCodeNotFound, "invalid code owners file", http.StatusBadRequest)
+	case service.ErrInvalidCapacity:
+		writeError(w, ErrorCodeNotFound, "max_open_reviews must not be negative", http.StatusBadRequest)
+	case service.ErrInvalidTag:
+		writeError(w, ErrorCodeNotFound, "tags must be non-empty and at most 64 characters", http.StatusBadRequest)
+	case service.ErrInvalidAbsence:
+		writeError(w, ErrorCodeNotFound, "absence must end after it starts", http.StatusBadRequest)
+	case service.ErrAbsenceNotFound:
+		writeError(w, ErrorCodeNotFound, "absence not found", http.StatusNotFound)
+	case service.ErrInvalidVerdict:
+		writeError(w, ErrorCodeNotFound, "verdict must be APPROVED, CHANGES_REQUESTED or COMMENTED", http.StatusBadRequest)
+	case service.ErrDeclineReason:
+		writeError(w, ErrorCodeNotFound, "decline reason is required", http.StatusBadRequest)
+	case service.ErrInvalidPriority:
+		writeError(w, ErrorCodeNotFound, "priority must be low, normal or urgent", http.StatusBadRequest)
+	case service.ErrInvalidSize:
+		writeError(w, ErrorCodeNotFound, "size must not be negative", http.StatusBadRequest)
+	case service.ErrInvalidLabel:
+		writeError(w, ErrorCodeNotFound, "labels must be non-empty and at most 64 characters", http.StatusBadRequest)
+	case service.ErrInvalidStatus:
+		writeError(w, ErrorCodeNotFound, "status must be DRAFT, OPEN, MERGED or CLOSED", http.StatusBadRequest)
+	case service.ErrInvalidCursor:
+		writeError(w, ErrorCodeNotFound, "malformed cursor", http.StatusBadRequest)
+	case service.ErrInvalidLimit:
+		writeError(w, ErrorCodeNotFound, "limit must be between 1 and 200", http.StatusBadRequest)
+	case service.ErrInvalidPRName:
+		writeError(w, ErrorCodeNotFound, "pull_request_name must not be empty", http.StatusBadRequest)
 	case service.ErrTeamNotFound:
 		writeError(w, ErrorCodeNotFound, "team not found", http.StatusNotFound)
 	case service.ErrUserNotFound:
 		writeError(w, ErrorCodeNotFound, "user not found", http.StatusNotFound)
+	case service.ErrUserOutsideTeam:
+		writeError(w, ErrorCodeNotFound, "user does not belong to the team", http.StatusBadRequest)
+	case service.ErrPlanNotFound:
+		writeError(w, ErrorCodeNotFound, "deactivation plan not found", http.StatusNotFound)
+	case service.ErrPlanExecuted:
+		writeError(w, ErrorCodePlanExecuted, "deactivation plan is already executed", http.StatusConflict)
+	case service.ErrPlanStale:
+		writeError(w, ErrorCodePlanStale, "reviews changed since the plan was computed, request a new dry run", http.StatusConflict)
+	case service.ErrJobNotFound:
+		writeError(w, ErrorCodeNotFound, "job not found", http.StatusNotFound)
+	case service.ErrInvalidJob:
+		writeError(w, ErrorCodeNotFound, "job needs items and every item must be complete", http.StatusBadRequest)
 	case service.ErrPRNotFound:
 		writeError(w, ErrorCodeNotFound, "PR not found", http.StatusNotFound)
 	case service.ErrPRExists:
@@ -59,8 +114,34 @@ func handleServiceError(w http.ResponseWriter, err error) {
 		writeError(w, ErrorCodePRMerged, "cannot reassign on merged PR", http.StatusConflict)
 	case service.ErrNotAssigned:
 		writeError(w, ErrorCodeNotAssigned, "reviewer is not assigned to this PR", http.StatusConflict)
+	case service.ErrNotApproved:
+		writeError(w, ErrorCodeNotApproved, "PR lacks required approvals or has changes requested", http.StatusConflict)
+	case service.ErrDependencyCycle:
+		writeError(w, ErrorCodeNotFound, "depends_on would make a dependency cycle", http.StatusBadRequest)
+	case service.ErrDependencyNotFound:
+		writeError(w, ErrorCodeNotFound, "dependency PR not found", http.StatusNotFound)
+	case service.ErrDependenciesNotMerged:
+		writeError(w, ErrorCodeDependencyNotMerged, "PRs this PR depends on are not merged", http.StatusConflict)
+	case service.ErrPRHasDependents:
+		writeError(w, ErrorCodeHasDependents, "other PRs depend on this PR", http.StatusConflict)
+	case service.ErrInvalidTransition:
+		writeError(w, ErrorCodeInvalidTransition, "PR status does not allow this transition", http.StatusConflict)
+	case service.ErrPRNotOpen:
+		writeError(w, ErrorCodePRNotOpen, "reviewers and reviews can only be changed on an OPEN PR", http.StatusConflict)
+	case service.ErrSelfReview:
+		writeError(w, ErrorCodeNoCandidate, "author cannot review own PR", http.StatusConflict)
+	case service.ErrReviewerUnavailable:
+		writeError(w, ErrorCodeNoCandidate, "reviewer is inactive or absent", http.StatusConflict)
+	case service.ErrReviewerOutsideTeam:
+		writeError(w, ErrorCodeNoCandidate, "reviewer is not in the author's team or its fallback teams", http.StatusConflict)
 	case service.ErrNoCandidate:
 		writeError(w, ErrorCodeNoCandidate, "no active replacement candidate in team", http.StatusConflict)
+	case service.ErrCapacityExhausted:
+		writeError(w, ErrorCodeCapacityExhausted, "all candidate reviewers are at capacity", http.StatusConflict)
+	case service.ErrReviewerCountOutOfRange:
+		writeError(w, ErrorCodeNotFound, "reviewer_count is out of team bounds", http.StatusBadRequest)
+	case service.ErrReviewerLimitReached:
+		writeError(w, ErrorCodeReviewerLimit, "PR already has max_reviewer_count reviewers", http.StatusConflict)
 	case service.ErrAuthorNotFound:
 		writeError(w, ErrorCodeNotFound, "author/team not found", http.StatusNotFound)
 	default:
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type JobHandler struct {
+	jobService *service.JobService
+}
+
+func NewJobHandler(jobService *service.JobService) *JobHandler {
+	return &JobHandler{jobService: jobService}
+}
+
+// GetJob handles GET /jobs/{id}
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jobID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, ErrorCodeNotFound, "job id must be an integer", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.GetJob(jobID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeJob(w, http.StatusOK, job)
+}
+
+// BulkDeactivate handles POST /jobs/bulkDeactivate
+func (h *JobHandler) BulkDeactivate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Items []service.BulkDeactivateJobItem `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.EnqueueBulkDeactivate(req.Items, ActorFrom(r))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeJob(w, http.StatusAccepted, job)
+}
+
+// ImportTeams handles POST /jobs/importTeams
+func (h *JobHandler) ImportTeams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Teams []domain.Team `json:"teams"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.EnqueueTeamImport(req.Teams, ActorFrom(r))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeJob(w, http.StatusAccepted, job)
+}
+
+// MassReassign handles POST /jobs/massReassign
+func (h *JobHandler) MassReassign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req service.MassReassignJob
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.EnqueueMassReassign(req, ActorFrom(r))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	writeJob(w, http.StatusAccepted, job)
+}
+
+func writeJob(w http.ResponseWriter, statusCode int, job *domain.Job) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(map[string]interface{}{"job": job}); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
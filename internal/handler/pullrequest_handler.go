@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/service"
@@ -11,10 +13,12 @@ import (
 
 type PullRequestHandler struct {
 	prService *service.PullRequestService
+	// adminToken authorizes forced merges
+	adminToken string
 }
 
-func NewPullRequestHandler(prService *service.PullRequestService) *PullRequestHandler {
-	return &PullRequestHandler{prService: prService}
+func NewPullRequestHandler(prService *service.PullRequestService, adminToken string) *PullRequestHandler {
+	return &PullRequestHandler{prService: prService, adminToken: adminToken}
 }
 
 // CreatePR handles POST /pullRequest/create
@@ -25,9 +29,18 @@ func (h *PullRequestHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		PullRequestID   string `json:"pull_request_id"`
-		PullRequestName string `json:"pull_request_name"`
-		AuthorID        string `json:"author_id"`
+		PullRequestID   string   `json:"pull_request_id"`
+		PullRequestName string   `json:"pull_request_name"`
+		AuthorID        string   `json:"author_id"`
+		ReviewerCount   *int     `json:"reviewer_count"`
+		ChangedFiles    []string `json:"changed_files"`
+		RequiredTags    []string `json:"required_tags"`
+		Draft           bool     `json:"draft"`
+		Description     string   `json:"description"`
+		Labels          []string `json:"labels"`
+		Priority        string   `json:"priority"`
+		Size            int      `json:"size"`
+		DependsOn       []string `json:"depends_on"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -39,9 +52,24 @@ func (h *PullRequestHandler) CreatePR(w http.ResponseWriter, r *http.Request) {
 		PullRequestID:   req.PullRequestID,
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
+		RequiredTags:    req.RequiredTags,
+		Description:     req.Description,
+		Labels:          req.Labels,
+		Priority:        domain.PRPriority(req.Priority),
+		Size:            req.Size,
+		DependsOn:       req.DependsOn,
+	}
+	if req.Draft {
+		pr.Status = domain.PRStatusDraft
+	}
+
+	opts := service.CreatePROptions{
+		ReviewerCount: req.ReviewerCount,
+		ChangedFiles:  req.ChangedFiles,
+		Actor:         ActorFrom(r),
 	}
 
-	if err := h.prService.CreatePR(pr); err != nil {
+	if err := h.prService.CreatePR(pr, opts); err != nil {
 		handleServiceError(w, err)
 		return
 	}
@@ -74,6 +102,143 @@ func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
+		// Force bypasses the approval gate (admin only)
+		Force bool `json:"force"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Force && !isAdmin(r, h.adminToken) {
+		writeError(w, ErrorCodeForbidden, "admin token required to force a merge", http.StatusForbidden)
+		return
+	}
+
+	pr, err := h.prService.MergePR(req.PullRequestID, req.Force, ActorFrom(r))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.PullRequest{
+		"pr": pr,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// UpdatePR handles POST /pullRequest/update; omitted fields are left as is
+func (h *PullRequestHandler) UpdatePR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID   string             `json:"pull_request_id"`
+		PullRequestName *string            `json:"pull_request_name"`
+		Description     *string            `json:"description"`
+		Labels          *[]string          `json:"labels"`
+		Priority        *domain.PRPriority `json:"priority"`
+		Size            *int               `json:"size"`
+		DependsOn       *[]string          `json:"depends_on"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.prService.UpdatePRMetadata(req.PullRequestID, service.PRMetadataUpdate{
+		PullRequestName: req.PullRequestName,
+		Description:     req.Description,
+		Labels:          req.Labels,
+		Priority:        req.Priority,
+		Size:            req.Size,
+		DependsOn:       req.DependsOn,
+	})
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.PullRequest{
+		"pr": pr,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// DeletePR handles POST /pullRequest/delete (admin only)
+func (h *PullRequestHandler) DeletePR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.prService.DeletePR(req.PullRequestID); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]string{
+		"pull_request_id": req.PullRequestID,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// AddReviewer handles POST /pullRequest/addReviewer
+func (h *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewer(w, r, h.prService.AddReviewer)
+}
+
+// RemoveReviewer handles POST /pullRequest/removeReviewer
+func (h *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	h.changeReviewer(w, r, h.prService.RemoveReviewer)
+}
+
+// changeReviewer decodes a manual reviewer change request and applies it with the given service method
+func (h *PullRequestHandler) changeReviewer(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(prID string, userID string, change domain.AssignmentChange) (*domain.PullRequest, error),
+) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Reason        string `json:"reason"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -81,7 +246,103 @@ func (h *PullRequestHandler) MergePR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prService.MergePR(req.PullRequestID)
+	pr, err := change(req.PullRequestID, req.UserID, domain.AssignmentChange{
+		Actor:  ActorFrom(r),
+		Reason: req.Reason,
+	})
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.PullRequest{
+		"pr": pr,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// MarkReady handles POST /pullRequest/ready
+func (h *PullRequestHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.MarkReady)
+}
+
+// ClosePR handles POST /pullRequest/close; closing assigns nobody, so it takes no assignment options
+func (h *PullRequestHandler) ClosePR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		Reason        string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.prService.ClosePR(req.PullRequestID, domain.AssignmentChange{
+		Actor:  ActorFrom(r),
+		Reason: req.Reason,
+	})
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.PullRequest{
+		"pr": pr,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// ReopenPR handles POST /pullRequest/reopen
+func (h *PullRequestHandler) ReopenPR(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.prService.ReopenPR)
+}
+
+// changeStatus decodes a status change request that assigns reviewers (ready, reopen) and applies it
+// with the given service method
+func (h *PullRequestHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(prID string, opts service.CreatePROptions) (*domain.PullRequest, error),
+) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID string   `json:"pull_request_id"`
+		ReviewerCount *int     `json:"reviewer_count"`
+		ChangedFiles  []string `json:"changed_files"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := change(req.PullRequestID, service.CreatePROptions{
+		ReviewerCount: req.ReviewerCount,
+		ChangedFiles:  req.ChangedFiles,
+		Actor:         ActorFrom(r),
+	})
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -109,6 +370,7 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 	var req struct {
 		PullRequestID string `json:"pull_request_id"`
 		OldUserID     string `json:"old_user_id"`
+		Reason        string `json:"reason"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -116,7 +378,10 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	pr, newUserID, err := h.prService.ReassignReviewer(req.PullRequestID, req.OldUserID)
+	pr, newUserID, err := h.prService.ReassignReviewer(req.PullRequestID, req.OldUserID, domain.AssignmentChange{
+		Actor:  ActorFrom(r),
+		Reason: req.Reason,
+	})
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -134,3 +399,197 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 		slog.Error("Failed to encode response", "error", err)
 	}
 }
+
+// GetHistory handles GET /pullRequest/history?pull_request_id=...
+func (h *PullRequestHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeError(w, ErrorCodeNotFound, "pull_request_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.prService.GetAssignmentHistory(prID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"pull_request_id": prID,
+		"events":          events,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// DeclineReview handles POST /pullRequest/decline
+func (h *PullRequestHandler) DeclineReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID string `json:"pull_request_id"`
+		UserID        string `json:"user_id"`
+		Reason        string `json:"reason"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pr, newUserID, err := h.prService.DeclineReview(req.PullRequestID, req.UserID, req.Reason)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	var replacedBy *string
+	if newUserID != "" {
+		replacedBy = &newUserID
+	}
+
+	response := map[string]interface{}{
+		"pr":          pr,
+		"replaced_by": replacedBy,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// SubmitReview handles POST /pullRequest/review
+func (h *PullRequestHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		PullRequestID string               `json:"pull_request_id"`
+		UserID        string               `json:"user_id"`
+		Verdict       domain.ReviewVerdict `json:"verdict"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.prService.SubmitReview(req.PullRequestID, req.UserID, req.Verdict)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.PullRequest{
+		"pr": pr,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// ListPRs handles GET /pullRequest/list?status=...&author_id=...&reviewer_id=...&team_name=...&label=...
+// &priority=...&created_from=...&created_to=...&merged_from=...&merged_to=...&order=asc&cursor=...&limit=...
+func (h *PullRequestHandler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+	query := domain.PRQuery{
+		Status:     domain.PRStatus(params.Get("status")),
+		AuthorID:   params.Get("author_id"),
+		ReviewerID: params.Get("reviewer_id"),
+		TeamName:   params.Get("team_name"),
+		Label:      params.Get("label"),
+		Priority:   domain.PRPriority(params.Get("priority")),
+	}
+
+	bounds := []struct {
+		name  string
+		value **time.Time
+	}{
+		{"created_from", &query.CreatedFrom},
+		{"created_to", &query.CreatedTo},
+		{"merged_from", &query.MergedFrom},
+		{"merged_to", &query.MergedTo},
+	}
+	for _, bound := range bounds {
+		value, err := parseTimeParam(params.Get(bound.name))
+		if err != nil {
+			writeError(w, ErrorCodeNotFound, bound.name+" must be an RFC 3339 time or a YYYY-MM-DD date", http.StatusBadRequest)
+			return
+		}
+		*bound.value = value
+	}
+
+	switch params.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		writeError(w, ErrorCodeNotFound, "order must be asc or desc", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if raw := params.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			writeError(w, ErrorCodeNotFound, "limit must be an integer", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.prService.ListPRs(query, params.Get("cursor"), limit)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// parseTimeParam parses an optional RFC 3339 time or YYYY-MM-DD date (midnight UTC) query parameter
+func parseTimeParam(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &parsed, nil
+	}
+	parsed, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
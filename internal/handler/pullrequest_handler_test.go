@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"avito-tech-internship/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestHandler_MergePR_ForceRequiresAdminToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		adminToken string
+		provided   string
+	}{
+		{name: "no token", adminToken: "secret"},
+		{name: "wrong token", adminToken: "secret", provided: "guess"},
+		{name: "admin endpoints disabled", adminToken: "", provided: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The service has no repositories: a forced merge must be rejected before reaching it
+			h := NewPullRequestHandler(service.NewPullRequestService(nil, nil, nil, nil), tt.adminToken)
+
+			req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge",
+				strings.NewReader(`{"pull_request_id":"pr-1","force":true}`))
+			if tt.provided != "" {
+				req.Header.Set(AdminTokenHeader, tt.provided)
+			}
+			rec := httptest.NewRecorder()
+
+			h.MergePR(rec, req)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			var resp ErrorResponse
+			assert.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+			assert.Equal(t, string(ErrorCodeForbidden), resp.Error.Code)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"avito-tech-internship/internal/service"
+)
+
+type SLAHandler struct {
+	slaService *service.SLAService
+}
+
+func NewSLAHandler(slaService *service.SLAService) *SLAHandler {
+	return &SLAHandler{slaService: slaService}
+}
+
+// GetOverdue handles GET /pullRequest/overdue?team_name=...
+func (h *SLAHandler) GetOverdue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	assignments, err := h.slaService.GetOverdueAssignments(r.URL.Query().Get("team_name"))
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"assignments": assignments,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
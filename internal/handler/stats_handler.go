@@ -16,14 +16,14 @@ func NewStatsHandler(prService *service.PullRequestService) *StatsHandler {
 	return &StatsHandler{prService: prService}
 }
 
-// GetStats handles GET /stats
+// GetStats handles GET /stats?include_archived=true
 func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	stats, err := h.prService.GetStats()
+	stats, err := h.prService.GetStats(r.URL.Query().Get("include_archived") == "true")
 	if err != nil {
 		slog.Error("Failed to get stats", "error", err)
 		writeError(w, ErrorCodeNotFound, "failed to get statistics", http.StatusInternalServerError)
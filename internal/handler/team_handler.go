@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -79,3 +80,161 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 		slog.Error("Failed to encode response", "error", err)
 	}
 }
+
+// GetSettings handles GET /team/getSettings?team_name=...
+func (h *TeamHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, ErrorCodeNotFound, "team_name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.teamService.GetTeamSettings(teamName)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.TeamSettings{
+		"settings": settings,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// SetSettings handles POST /team/setSettings. Fields missing from the body keep their current values
+func (h *TeamHandler) SetSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		TeamName string `json:"team_name"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" {
+		writeError(w, ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	settings, err := h.teamService.GetTeamSettings(req.TeamName)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	if err := json.Unmarshal(body, settings); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	settings.TeamName = req.TeamName
+
+	if err := h.teamService.UpdateTeamSettings(settings); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.TeamSettings{
+		"settings": settings,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// GetCodeOwners handles GET /team/getCodeOwners?team_name=...
+func (h *TeamHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, ErrorCodeNotFound, "team_name parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	content, err := h.teamService.GetCodeOwners(teamName)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]string{
+		"team_name":   teamName,
+		"code_owners": content,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// SetCodeOwners handles POST /team/setCodeOwners
+func (h *TeamHandler) SetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		TeamName   string `json:"team_name"`
+		CodeOwners string `json:"code_owners"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.TeamName == "" {
+		writeError(w, ErrorCodeNotFound, "team_name is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.teamService.SetCodeOwners(req.TeamName, req.CodeOwners); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]string{
+		"team_name":   req.TeamName,
+		"code_owners": req.CodeOwners,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
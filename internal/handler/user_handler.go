@@ -56,7 +56,126 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetReview handles GET /users/getReview?user_id=...
+// SetMaxOpenReviews handles POST /users/setMaxOpenReviews
+func (h *UserHandler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID         string `json:"user_id"`
+		MaxOpenReviews *int   `json:"max_open_reviews"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userService.SetMaxOpenReviews(req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.User{
+		"user": user,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// GetTags handles GET /users/tags?user_id=...
+func (h *UserHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, ErrorCodeNotFound, "user_id parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.userService.GetUserTags(userID)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]interface{}{
+		"user_id": userID,
+		"tags":    tags,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// AddTags handles POST /users/tags/add
+func (h *UserHandler) AddTags(w http.ResponseWriter, r *http.Request) {
+	h.updateTags(w, r, h.userService.AddUserTags)
+}
+
+// RemoveTags handles POST /users/tags/remove
+func (h *UserHandler) RemoveTags(w http.ResponseWriter, r *http.Request) {
+	h.updateTags(w, r, h.userService.RemoveUserTags)
+}
+
+// updateTags decodes a {user_id, tags} request and applies update to it
+func (h *UserHandler) updateTags(
+	w http.ResponseWriter,
+	r *http.Request,
+	update func(userID string, tags []string) (*domain.User, error),
+) {
+	if r.Method != http.MethodPost {
+		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		UserID string   `json:"user_id"`
+		Tags   []string `json:"tags"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, ErrorCodeNotFound, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Tags == nil {
+		req.Tags = []string{}
+	}
+
+	user, err := update(req.UserID, req.Tags)
+	if err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	response := map[string]*domain.User{
+		"user": user,
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// GetReview handles GET /users/getReview?user_id=...&awaiting_verdict=true&priority=...&label=...&include_archived=true
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		writeError(w, ErrorCodeNotFound, "method not allowed", http.StatusMethodNotAllowed)
@@ -69,7 +188,18 @@ func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	prs, err := h.pullRequestService.GetPRsByReviewer(userID)
+	filter := service.PRListFilter{
+		AwaitingVerdict: r.URL.Query().Get("awaiting_verdict") == "true",
+		IncludeArchived: r.URL.Query().Get("include_archived") == "true",
+		Priority:        domain.PRPriority(r.URL.Query().Get("priority")),
+		Label:           r.URL.Query().Get("label"),
+	}
+	if err := filter.Validate(); err != nil {
+		handleServiceError(w, err)
+		return
+	}
+
+	prs, err := h.pullRequestService.GetPRsByReviewer(userID, filter)
 	if err != nil {
 		handleServiceError(w, err)
 		return
@@ -0,0 +1,4 @@
+-- Add per-team reviewer selection strategy
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewer_strategy VARCHAR(32) NOT NULL DEFAULT 'random'
+    CHECK (reviewer_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted'));
@@ -0,0 +1,6 @@
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS overflow_team,
+    DROP COLUMN IF EXISTS capacity_overflow,
+    DROP COLUMN IF EXISTS default_max_open_reviews;
+
+ALTER TABLE users DROP COLUMN IF EXISTS max_open_reviews;
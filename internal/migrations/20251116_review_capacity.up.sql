@@ -0,0 +1,10 @@
+-- Per-user review capacity
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL CHECK (max_open_reviews >= 0);
+
+-- Team-level capacity defaults and overflow handling
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS default_max_open_reviews INTEGER NULL CHECK (default_max_open_reviews >= 0),
+    ADD COLUMN IF NOT EXISTS capacity_overflow VARCHAR(32) NOT NULL DEFAULT 'assign_fewer'
+        CHECK (capacity_overflow IN ('assign_fewer', 'overflow_team', 'reject')),
+    ADD COLUMN IF NOT EXISTS overflow_team VARCHAR(255) NULL REFERENCES teams(team_name) ON DELETE SET NULL;
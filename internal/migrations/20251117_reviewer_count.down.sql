@@ -0,0 +1,6 @@
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_reviewer_count_bounds;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS max_reviewer_count,
+    DROP COLUMN IF EXISTS min_reviewer_count,
+    DROP COLUMN IF EXISTS reviewer_count;
@@ -0,0 +1,9 @@
+-- Per-team reviewer count with bounds for per-PR overrides
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewer_count INTEGER NOT NULL DEFAULT 2,
+    ADD COLUMN IF NOT EXISTS min_reviewer_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS max_reviewer_count INTEGER NOT NULL DEFAULT 2;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_reviewer_count_bounds
+    CHECK (0 <= min_reviewer_count AND min_reviewer_count <= reviewer_count AND reviewer_count <= max_reviewer_count);
@@ -0,0 +1,6 @@
+-- CODEOWNERS-style ownership file per team
+CREATE TABLE IF NOT EXISTS team_code_owners (
+    team_name VARCHAR(255) PRIMARY KEY REFERENCES teams(team_name) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS pr_required_tags;
+DROP INDEX IF EXISTS idx_user_tags_tag;
+DROP TABLE IF EXISTS user_tags;
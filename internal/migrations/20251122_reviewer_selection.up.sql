@@ -0,0 +1,3 @@
+-- Strategy and random seed each reviewer was picked with, so assignments can be replayed
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS selection_strategy VARCHAR(32) NULL;
+ALTER TABLE pr_reviewers ADD COLUMN IF NOT EXISTS selection_seed BIGINT NULL;
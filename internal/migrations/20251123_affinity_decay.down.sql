@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_author_created;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_affinity_window_days_check;
+ALTER TABLE teams DROP COLUMN IF EXISTS affinity_window_days;
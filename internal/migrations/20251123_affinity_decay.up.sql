@@ -0,0 +1,9 @@
+-- Days of assignment history used to down-weight reviewers who recently reviewed the same author (0 = disabled)
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS affinity_window_days INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_affinity_window_days_check
+    CHECK (affinity_window_days >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_created ON pull_requests(author_id, created_at);
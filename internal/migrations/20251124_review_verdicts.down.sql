@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pr_reviews_pr_user;
+DROP TABLE IF EXISTS pr_reviews;
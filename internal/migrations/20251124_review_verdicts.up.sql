@@ -0,0 +1,10 @@
+-- Verdicts submitted by assigned reviewers; the latest one per reviewer is the reviewer's state
+CREATE TABLE IF NOT EXISTS pr_reviews (
+    review_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE RESTRICT,
+    verdict VARCHAR(32) NOT NULL CHECK (verdict IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviews_pr_user ON pr_reviews(pull_request_id, user_id, submitted_at);
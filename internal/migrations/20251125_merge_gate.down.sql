@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS force_merged;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_required_approvals_check;
+ALTER TABLE teams DROP COLUMN IF EXISTS required_approvals;
@@ -0,0 +1,11 @@
+-- Number of APPROVED verdicts a PR of the team needs before it can be merged (0 = no approvals required)
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_required_approvals_check
+    CHECK (required_approvals >= 0);
+
+-- Set when the PR was merged with force, bypassing the approval gate
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS force_merged BOOLEAN NOT NULL DEFAULT FALSE;
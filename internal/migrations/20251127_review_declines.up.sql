@@ -0,0 +1,11 @@
+-- Reviews declined by assigned reviewers; replaced_by is NULL when nobody could take the review over
+CREATE TABLE IF NOT EXISTS pr_declines (
+    decline_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(user_id) ON DELETE RESTRICT,
+    reason TEXT NOT NULL,
+    replaced_by VARCHAR(255) NULL REFERENCES users(user_id) ON DELETE RESTRICT,
+    declined_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_declines_user ON pr_declines(user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_overdue;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS overdue_at;
+ALTER TABLE pr_reviewers DROP COLUMN IF EXISTS assigned_at;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_review_sla_hours_check;
+ALTER TABLE teams DROP COLUMN IF EXISTS sla_reassign;
+ALTER TABLE teams DROP COLUMN IF EXISTS review_sla_hours;
@@ -0,0 +1,20 @@
+-- Hours an assigned reviewer has to submit a verdict before the assignment becomes overdue (0 = no SLA)
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS review_sla_hours INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS sla_reassign BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_review_sla_hours_check
+    CHECK (review_sla_hours >= 0);
+
+ALTER TABLE pr_reviewers
+    ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS overdue_at TIMESTAMPTZ NULL;
+
+-- Existing assignments are treated as made when their PR was created
+UPDATE pr_reviewers prr
+SET assigned_at = pr.created_at
+FROM pull_requests pr
+WHERE pr.pull_request_id = prr.pull_request_id AND pr.created_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_overdue ON pr_reviewers(overdue_at) WHERE overdue_at IS NOT NULL;
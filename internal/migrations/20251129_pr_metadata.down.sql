@@ -0,0 +1,8 @@
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_large_pr_lines_check;
+ALTER TABLE teams DROP COLUMN IF EXISTS large_pr_lines;
+DROP TABLE IF EXISTS pr_labels;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_size_check;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_priority_check;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS size;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS priority;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS description;
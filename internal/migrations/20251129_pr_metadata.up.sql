@@ -0,0 +1,29 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS priority VARCHAR(16) NOT NULL DEFAULT 'normal',
+    ADD COLUMN IF NOT EXISTS size INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_priority_check
+    CHECK (priority IN ('low', 'normal', 'urgent'));
+
+-- Size is the number of changed lines (0 = unknown)
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_size_check
+    CHECK (size >= 0);
+
+CREATE TABLE IF NOT EXISTS pr_labels (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    label VARCHAR(64) NOT NULL,
+    PRIMARY KEY (pull_request_id, label)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_labels_label ON pr_labels(label);
+
+-- PRs of at least this many changed lines get one more reviewer (0 = disabled)
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS large_pr_lines INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_large_pr_lines_check
+    CHECK (large_pr_lines >= 0);
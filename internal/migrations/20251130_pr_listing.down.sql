@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pull_requests_merged;
+DROP INDEX IF EXISTS idx_pull_requests_created;
+ALTER TABLE pull_requests ALTER COLUMN created_at DROP NOT NULL;
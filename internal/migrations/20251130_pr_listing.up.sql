@@ -0,0 +1,7 @@
+-- PR listings page by (created_at, pull_request_id), so every PR needs a creation time
+UPDATE pull_requests SET created_at = CURRENT_TIMESTAMP WHERE created_at IS NULL;
+
+ALTER TABLE pull_requests ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_created ON pull_requests(created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged ON pull_requests(merged_at) WHERE merged_at IS NOT NULL;
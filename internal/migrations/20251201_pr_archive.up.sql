@@ -0,0 +1,3 @@
+-- Archived PRs are hidden from reviewer lists and stats by default
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ NULL;
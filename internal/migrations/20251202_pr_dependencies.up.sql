@@ -0,0 +1,9 @@
+-- Stacked PRs: a PR can be merged only after every PR it depends on is merged
+CREATE TABLE IF NOT EXISTS pr_dependencies (
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    depends_on_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    PRIMARY KEY (pull_request_id, depends_on_id),
+    CHECK (pull_request_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_dependencies_depends_on ON pr_dependencies(depends_on_id);
@@ -0,0 +1,21 @@
+-- Append-only history of reviewer assignment changes
+CREATE TABLE IF NOT EXISTS assignment_events (
+    event_id BIGSERIAL PRIMARY KEY,
+    pull_request_id VARCHAR(255) NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL CHECK (event_type IN ('assign', 'unassign', 'reassign', 'bulk_deactivate')),
+    user_id VARCHAR(255) NOT NULL,
+    replacement_id VARCHAR(255) NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    strategy VARCHAR(32) NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pr ON assignment_events(pull_request_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_assignment_events_user ON assignment_events(user_id);
+
+-- Current assignments are recorded as made when the PR was created, by an unknown actor
+INSERT INTO assignment_events (pull_request_id, event_type, user_id, actor, reason, strategy, created_at)
+SELECT prr.pull_request_id, 'assign', prr.user_id, 'migration', 'assigned before history was kept',
+       prr.selection_strategy, prr.assigned_at
+FROM pr_reviewers prr;
@@ -0,0 +1,15 @@
+-- Audit log of mutating API calls
+CREATE TABLE IF NOT EXISTS audit_log (
+    record_id BIGSERIAL PRIMARY KEY,
+    actor VARCHAR(255) NOT NULL,
+    method VARCHAR(16) NOT NULL,
+    endpoint VARCHAR(255) NOT NULL,
+    request_summary TEXT NOT NULL DEFAULT '',
+    result_code INTEGER NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor, record_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_endpoint ON audit_log(endpoint, record_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS deactivation_plan_steps;
+DROP TABLE IF EXISTS deactivation_plans;
@@ -0,0 +1,22 @@
+-- Bulk deactivation plans computed by dry runs
+CREATE TABLE IF NOT EXISTS deactivation_plans (
+    plan_id BIGSERIAL PRIMARY KEY,
+    team_name VARCHAR(255) NOT NULL REFERENCES teams(team_name) ON DELETE CASCADE,
+    user_ids TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    executed_at TIMESTAMPTZ NULL
+);
+
+-- Steps keep no foreign keys: a PR or user changed since the dry run makes the plan stale instead
+CREATE TABLE IF NOT EXISTS deactivation_plan_steps (
+    plan_id BIGINT NOT NULL REFERENCES deactivation_plans(plan_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    pull_request_id VARCHAR(255) NOT NULL,
+    old_user_id VARCHAR(255) NOT NULL,
+    new_user_id VARCHAR(255) NULL,
+    is_fallback BOOLEAN NOT NULL DEFAULT FALSE,
+    selection_strategy VARCHAR(32) NULL,
+    selection_seed BIGINT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (plan_id, position)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_item_errors;
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,27 @@
+-- Bulk operations run asynchronously by the worker pool
+CREATE TABLE IF NOT EXISTS jobs (
+    job_id BIGSERIAL PRIMARY KEY,
+    job_type VARCHAR(32) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'queued',
+    actor VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    total_items INTEGER NOT NULL,
+    processed_items INTEGER NOT NULL DEFAULT 0,
+    failed_items INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    -- A running job whose lease expired was abandoned by its worker and is picked up again
+    lease_expires_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMPTZ NULL,
+    finished_at TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs(job_id) WHERE status IN ('queued', 'running');
+
+CREATE TABLE IF NOT EXISTS job_item_errors (
+    job_id BIGINT NOT NULL REFERENCES jobs(job_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    item VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    PRIMARY KEY (job_id, position)
+);
@@ -0,0 +1,5 @@
+-- Deleting a PR others depend on would silently unblock their merge, so it is rejected instead
+ALTER TABLE pr_dependencies
+    DROP CONSTRAINT IF EXISTS pr_dependencies_depends_on_id_fkey,
+    ADD CONSTRAINT pr_dependencies_depends_on_id_fkey
+        FOREIGN KEY (depends_on_id) REFERENCES pull_requests(pull_request_id) ON DELETE RESTRICT;
@@ -0,0 +1,7 @@
+DELETE FROM assignment_events WHERE event_type = 'force_merge';
+
+ALTER TABLE assignment_events
+    ALTER COLUMN user_id SET NOT NULL,
+    DROP CONSTRAINT IF EXISTS assignment_events_event_type_check,
+    ADD CONSTRAINT assignment_events_event_type_check
+        CHECK (event_type IN ('assign', 'unassign', 'reassign', 'bulk_deactivate'));
@@ -0,0 +1,6 @@
+-- Forced merges are recorded in the PR history; they change no reviewer, so they have no user
+ALTER TABLE assignment_events
+    DROP CONSTRAINT IF EXISTS assignment_events_event_type_check,
+    ADD CONSTRAINT assignment_events_event_type_check
+        CHECK (event_type IN ('assign', 'unassign', 'reassign', 'bulk_deactivate', 'force_merge')),
+    ALTER COLUMN user_id DROP NOT NULL;
@@ -0,0 +1,6 @@
+DELETE FROM assignment_events e
+WHERE NOT EXISTS (SELECT 1 FROM pull_requests pr WHERE pr.pull_request_id = e.pull_request_id);
+
+ALTER TABLE assignment_events
+    ADD CONSTRAINT assignment_events_pull_request_id_fkey
+        FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
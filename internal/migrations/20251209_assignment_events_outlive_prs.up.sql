@@ -0,0 +1,2 @@
+-- The assignment history is an audit trail, so it is kept when its PR is deleted
+ALTER TABLE assignment_events DROP CONSTRAINT IF EXISTS assignment_events_pull_request_id_fkey;
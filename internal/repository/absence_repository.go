@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"time"
+
+	"avito-tech-internship/internal/domain"
+)
+
+// AbsenceRepository defines the interface for user absence operations
+type AbsenceRepository interface {
+	// CreateAbsence stores a new absence and fills its ID and creation time
+	CreateAbsence(absence *domain.Absence) error
+
+	// ListAbsences returns absences of a user ordered by start; past and cancelled ones only when includePast is set
+	ListAbsences(userID string, includePast bool) ([]*domain.Absence, error)
+
+	// CancelAbsence marks an absence as cancelled (idempotent)
+	CancelAbsence(absenceID int64) (*domain.Absence, error)
+
+	// GetAbsencesToReassign returns active absences started by now whose reviews still have to be reassigned
+	GetAbsencesToReassign(now time.Time) ([]*domain.Absence, error)
+
+	// MarkReassigned records that reviews of the absent user were reassigned
+	MarkReassigned(absenceID int64) error
+}
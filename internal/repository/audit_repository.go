@@ -0,0 +1,13 @@
+package repository
+
+import "avito-tech-internship/internal/domain"
+
+// AuditRepository defines the interface for audit log operations
+type AuditRepository interface {
+	// CreateRecord appends a record to the audit log and fills its ID and creation time
+	CreateRecord(record *domain.AuditRecord) error
+
+	// ListRecords returns up to limit records matching the query, newest first,
+	// with IDs below beforeID (0 = from the newest)
+	ListRecords(query domain.AuditQuery, beforeID int64, limit int) ([]*domain.AuditRecord, error)
+}
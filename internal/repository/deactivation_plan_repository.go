@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"avito-tech-internship/internal/domain"
+)
+
+// DeactivationPlanRepository defines the interface for bulk deactivation plan operations
+type DeactivationPlanRepository interface {
+	// CreatePlan stores a plan with its steps and fills its ID and creation time
+	CreatePlan(plan *domain.BulkDeactivatePlan) error
+
+	// GetPlan retrieves a plan with its steps, locking it until the transaction ends
+	GetPlan(planID int64) (*domain.BulkDeactivatePlan, error)
+
+	// MarkPlanExecuted records that the plan was executed
+	MarkPlanExecuted(planID int64, executedAt time.Time) error
+}
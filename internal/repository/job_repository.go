@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"time"
+
+	"avito-tech-internship/internal/domain"
+)
+
+// JobRepository defines the interface for asynchronous job operations
+type JobRepository interface {
+	// CreateJob queues a job and fills its ID, status and creation time
+	CreateJob(job *domain.Job) error
+
+	// GetJob retrieves a job with the errors of its failed items
+	GetJob(jobID int64) (*domain.Job, error)
+
+	// ClaimJob marks the oldest queued job, or a running job whose lease expired, as running
+	// under a new lease and returns it; ErrNotFound when there is none
+	ClaimJob(lease time.Duration) (*domain.Job, error)
+
+	// ExtendLease keeps a running job claimed for another lease
+	ExtendLease(jobID int64, lease time.Duration) error
+
+	// RecordProgress stores how many items are processed and failed, the error of the item at position
+	// if it failed, and extends the lease of the job. ErrNotFound when the job is not running with position
+	// items processed, as another worker took it over after the lease expired and moved on
+	RecordProgress(jobID int64, position int, failed int, itemErr *domain.JobItemError, lease time.Duration) error
+
+	// FinishJob sets the final status of a job with an error explaining a failure
+	FinishJob(jobID int64, status domain.JobStatus, errMsg string) error
+
+	// ReleaseJob puts a running job back in the queue so that it is resumed by the next claim
+	ReleaseJob(jobID int64) error
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+const absenceColumns = `absence_id, user_id, starts_at, ends_at, reason, reassign_reviews,
+	reassigned_at, cancelled_at, created_at`
+
+type absenceRepository struct {
+	db executor
+}
+
+// NewAbsenceRepository creates a new PostgreSQL absence repository
+func NewAbsenceRepository(db *sql.DB) *absenceRepository {
+	return &absenceRepository{db: db}
+}
+
+func (r *absenceRepository) CreateAbsence(absence *domain.Absence) error {
+	var createdAt time.Time
+	err := r.db.QueryRow(
+		`INSERT INTO user_absences (user_id, starts_at, ends_at, reason, reassign_reviews)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING absence_id, created_at`,
+		absence.UserID, absence.StartsAt, absence.EndsAt, absence.Reason, absence.ReassignReviews,
+	).Scan(&absence.AbsenceID, &createdAt)
+	if err != nil {
+		return fmt.Errorf("failed to create absence: %w", err)
+	}
+
+	absence.CreatedAt = &createdAt
+	return nil
+}
+
+func (r *absenceRepository) ListAbsences(userID string, includePast bool) ([]*domain.Absence, error) {
+	query := "SELECT " + absenceColumns + " FROM user_absences WHERE user_id = $1"
+	if !includePast {
+		query += " AND cancelled_at IS NULL AND ends_at > NOW()"
+	}
+	query += " ORDER BY starts_at, absence_id"
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query absences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *absenceRepository) CancelAbsence(absenceID int64) (*domain.Absence, error) {
+	row := r.db.QueryRow(
+		`UPDATE user_absences SET cancelled_at = COALESCE(cancelled_at, NOW())
+		 WHERE absence_id = $1
+		 RETURNING `+absenceColumns,
+		absenceID,
+	)
+
+	absence, err := scanAbsence(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to cancel absence: %w", err)
+	}
+	return absence, nil
+}
+
+func (r *absenceRepository) GetAbsencesToReassign(now time.Time) ([]*domain.Absence, error) {
+	rows, err := r.db.Query(
+		`SELECT `+absenceColumns+` FROM user_absences
+		 WHERE reassign_reviews AND reassigned_at IS NULL AND cancelled_at IS NULL
+		   AND starts_at <= $1 AND ends_at > $1
+		 ORDER BY starts_at, absence_id`,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query absences to reassign: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *absenceRepository) MarkReassigned(absenceID int64) error {
+	_, err := r.db.Exec(
+		"UPDATE user_absences SET reassigned_at = NOW() WHERE absence_id = $1",
+		absenceID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark absence reassigned: %w", err)
+	}
+	return nil
+}
+
+// scanAbsence scans a row selected with absenceColumns
+func scanAbsence(row interface{ Scan(dest ...any) error }) (*domain.Absence, error) {
+	var absence domain.Absence
+	var reassignedAt, cancelledAt, createdAt sql.NullTime
+
+	if err := row.Scan(
+		&absence.AbsenceID,
+		&absence.UserID,
+		&absence.StartsAt,
+		&absence.EndsAt,
+		&absence.Reason,
+		&absence.ReassignReviews,
+		&reassignedAt,
+		&cancelledAt,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	absence.ReassignedAt = nullTimePtr(reassignedAt)
+	absence.CancelledAt = nullTimePtr(cancelledAt)
+	absence.CreatedAt = nullTimePtr(createdAt)
+	return &absence, nil
+}
+
+func scanAbsences(rows *sql.Rows) ([]*domain.Absence, error) {
+	absences := []*domain.Absence{}
+	for rows.Next() {
+		absence, err := scanAbsence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan absence: %w", err)
+		}
+		absences = append(absences, absence)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating absences: %w", err)
+	}
+
+	return absences, nil
+}
+
+// nullTimePtr converts a nullable timestamp column into an optional time
+func nullTimePtr(v sql.NullTime) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Time
+}
@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"avito-tech-internship/internal/domain"
+)
+
+const auditColumns = "record_id, actor, method, endpoint, request_summary, result_code, request_id, created_at"
+
+type auditRepository struct {
+	db executor
+}
+
+// NewAuditRepository creates a new PostgreSQL audit log repository
+func NewAuditRepository(db *sql.DB) *auditRepository {
+	return &auditRepository{db: db}
+}
+
+func (r *auditRepository) CreateRecord(record *domain.AuditRecord) error {
+	err := r.db.QueryRow(
+		`INSERT INTO audit_log (actor, method, endpoint, request_summary, result_code, request_id)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING record_id, created_at`,
+		record.Actor, record.Method, record.Endpoint, record.RequestSummary, record.ResultCode, record.RequestID,
+	).Scan(&record.RecordID, &record.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create audit record: %w", err)
+	}
+	return nil
+}
+
+func (r *auditRepository) ListRecords(query domain.AuditQuery, beforeID int64, limit int) ([]*domain.AuditRecord, error) {
+	var conditions []string
+	var args []interface{}
+	// where adds a condition on the next argument, referenced as $%d in the condition
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.Actor != "" {
+		where("actor = $%d", query.Actor)
+	}
+	if query.Endpoint != "" {
+		where("endpoint = $%d", query.Endpoint)
+	}
+	if query.ResultCode != 0 {
+		where("result_code = $%d", query.ResultCode)
+	}
+	if query.From != nil {
+		where("created_at >= $%d", *query.From)
+	}
+	if query.To != nil {
+		where("created_at < $%d", *query.To)
+	}
+	if beforeID > 0 {
+		where("record_id < $%d", beforeID)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.Query(fmt.Sprintf(
+		`SELECT `+auditColumns+`
+		 FROM audit_log
+		 %s
+		 ORDER BY record_id DESC
+		 LIMIT $%d`,
+		whereClause, len(args),
+	), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	records := []*domain.AuditRecord{}
+	for rows.Next() {
+		var record domain.AuditRecord
+		if err := rows.Scan(
+			&record.RecordID, &record.Actor, &record.Method, &record.Endpoint, &record.RequestSummary,
+			&record.ResultCode, &record.RequestID, &record.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit record: %w", err)
+		}
+		records = append(records, &record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating audit log: %w", err)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+type deactivationPlanRepository struct {
+	db executor
+}
+
+// NewDeactivationPlanRepository creates a new PostgreSQL bulk deactivation plan repository
+func NewDeactivationPlanRepository(db *sql.DB) *deactivationPlanRepository {
+	return &deactivationPlanRepository{db: db}
+}
+
+func (r *deactivationPlanRepository) CreatePlan(plan *domain.BulkDeactivatePlan) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	err = tx.QueryRow(
+		"INSERT INTO deactivation_plans (team_name, user_ids) VALUES ($1, $2) RETURNING plan_id, created_at",
+		plan.TeamName, pq.Array(plan.UserIDs),
+	).Scan(&plan.PlanID, &plan.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	for position, step := range plan.Steps {
+		var newUserID, strategy sql.NullString
+		var seed sql.NullInt64
+		if step.Replacement != nil {
+			newUserID = sql.NullString{String: step.Replacement.UserID, Valid: true}
+			strategy = sql.NullString{String: string(step.Replacement.Strategy), Valid: true}
+			seed = sql.NullInt64{Int64: step.Replacement.Seed, Valid: true}
+		}
+
+		_, err = tx.Exec(
+			`INSERT INTO deactivation_plan_steps
+			     (plan_id, position, pull_request_id, old_user_id, new_user_id, is_fallback,
+			      selection_strategy, selection_seed, reason)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			plan.PlanID, position, step.PullRequestID, step.OldUserID, newUserID, step.IsFallback,
+			strategy, seed, step.Reason,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store plan step: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit plan creation: %w", err)
+	}
+	return nil
+}
+
+func (r *deactivationPlanRepository) GetPlan(planID int64) (*domain.BulkDeactivatePlan, error) {
+	var plan domain.BulkDeactivatePlan
+	var executedAt sql.NullTime
+	err := r.db.QueryRow(
+		`SELECT plan_id, team_name, user_ids, created_at, executed_at
+		 FROM deactivation_plans WHERE plan_id = $1
+		 FOR UPDATE`,
+		planID,
+	).Scan(&plan.PlanID, &plan.TeamName, pq.Array(&plan.UserIDs), &plan.CreatedAt, &executedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get plan: %w", err)
+	}
+	plan.ExecutedAt = nullTimePtr(executedAt)
+
+	rows, err := r.db.Query(
+		`SELECT pull_request_id, old_user_id, new_user_id, is_fallback, selection_strategy, selection_seed, reason
+		 FROM deactivation_plan_steps WHERE plan_id = $1 ORDER BY position`,
+		planID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query plan steps: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step domain.DeactivationStep
+		var newUserID, strategy sql.NullString
+		var seed sql.NullInt64
+		if err := rows.Scan(
+			&step.PullRequestID, &step.OldUserID, &newUserID, &step.IsFallback, &strategy, &seed, &step.Reason,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan plan step: %w", err)
+		}
+		if newUserID.Valid {
+			step.Replacement = &domain.ReviewerSelection{
+				UserID:   newUserID.String,
+				Strategy: domain.ReviewerStrategy(strategy.String),
+				Seed:     seed.Int64,
+			}
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating plan steps: %w", err)
+	}
+
+	return &plan, nil
+}
+
+func (r *deactivationPlanRepository) MarkPlanExecuted(planID int64, executedAt time.Time) error {
+	result, err := r.db.Exec(
+		"UPDATE deactivation_plans SET executed_at = $1 WHERE plan_id = $2",
+		executedAt, planID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark plan executed: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check executed plan: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
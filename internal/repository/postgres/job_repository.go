@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+const jobColumns = `job_id, job_type, status, actor, payload, total_items, processed_items, failed_items, error,
+	created_at, started_at, finished_at`
+
+type jobRepository struct {
+	db executor
+}
+
+// NewJobRepository creates a new PostgreSQL job repository
+func NewJobRepository(db *sql.DB) *jobRepository {
+	return &jobRepository{db: db}
+}
+
+func (r *jobRepository) CreateJob(job *domain.Job) error {
+	err := r.db.QueryRow(
+		`INSERT INTO jobs (job_type, actor, payload, total_items)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING job_id, status, created_at`,
+		job.Type, job.Actor, []byte(job.Payload), job.TotalItems,
+	).Scan(&job.JobID, &job.Status, &job.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRepository) GetJob(jobID int64) (*domain.Job, error) {
+	job, err := scanJob(r.db.QueryRow("SELECT "+jobColumns+" FROM jobs WHERE job_id = $1", jobID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	rows, err := r.db.Query(
+		"SELECT item, message FROM job_item_errors WHERE job_id = $1 ORDER BY position",
+		jobID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job item errors: %w", err)
+	}
+	defer rows.Close()
+
+	job.Errors = []domain.JobItemError{}
+	for rows.Next() {
+		var itemErr domain.JobItemError
+		if err := rows.Scan(&itemErr.Item, &itemErr.Message); err != nil {
+			return nil, fmt.Errorf("failed to scan job item error: %w", err)
+		}
+		job.Errors = append(job.Errors, itemErr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating job item errors: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *jobRepository) ClaimJob(lease time.Duration) (*domain.Job, error) {
+	// SKIP LOCKED lets every worker claim a different job without waiting for the others
+	job, err := scanJob(r.db.QueryRow(
+		`UPDATE jobs
+		 SET status = 'running',
+		     lease_expires_at = NOW() + make_interval(secs => $1),
+		     started_at = COALESCE(started_at, NOW())
+		 WHERE job_id = (
+		     SELECT job_id FROM jobs
+		     WHERE status = 'queued' OR (status = 'running' AND lease_expires_at < NOW())
+		     ORDER BY job_id
+		     LIMIT 1
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING `+jobColumns,
+		lease.Seconds(),
+	))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return job, nil
+}
+
+func (r *jobRepository) ExtendLease(jobID int64, lease time.Duration) error {
+	_, err := r.db.Exec(
+		`UPDATE jobs SET lease_expires_at = NOW() + make_interval(secs => $1)
+		 WHERE job_id = $2 AND status = 'running'`,
+		lease.Seconds(), jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to extend job lease: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRepository) RecordProgress(
+	jobID int64,
+	position int,
+	failed int,
+	itemErr *domain.JobItemError,
+	lease time.Duration,
+) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	// The job row stays locked until the transaction ends, so of two workers running the same item after
+	// a lease expired only the first records it; the other finds the position moved and rolls back
+	result, err := tx.Exec(
+		`UPDATE jobs
+		 SET processed_items = $1, failed_items = $2, lease_expires_at = NOW() + make_interval(secs => $3)
+		 WHERE job_id = $4 AND status = 'running' AND processed_items = $5`,
+		position+1, failed, lease.Seconds(), jobID, position,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record job progress: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check job progress: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	if itemErr != nil {
+		// An item interrupted by a restart is processed again, so its error may already be recorded
+		_, err = tx.Exec(
+			`INSERT INTO job_item_errors (job_id, position, item, message)
+			 VALUES ($1, $2, $3, $4)
+			 ON CONFLICT (job_id, position) DO UPDATE SET item = EXCLUDED.item, message = EXCLUDED.message`,
+			jobID, position, itemErr.Item, itemErr.Message,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record job item error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit job progress: %w", err)
+	}
+	return nil
+}
+
+func (r *jobRepository) FinishJob(jobID int64, status domain.JobStatus, errMsg string) error {
+	result, err := r.db.Exec(
+		`UPDATE jobs SET status = $1, error = $2, finished_at = NOW(), lease_expires_at = NULL
+		 WHERE job_id = $3`,
+		status, errMsg, jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check finished job: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *jobRepository) ReleaseJob(jobID int64) error {
+	_, err := r.db.Exec(
+		"UPDATE jobs SET status = 'queued', lease_expires_at = NULL WHERE job_id = $1 AND status = 'running'",
+		jobID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release job: %w", err)
+	}
+	return nil
+}
+
+// scanJob scans a row of jobColumns
+func scanJob(row *sql.Row) (*domain.Job, error) {
+	var job domain.Job
+	var payload []byte
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(
+		&job.JobID, &job.Type, &job.Status, &job.Actor, &payload, &job.TotalItems, &job.ProcessedItems,
+		&job.FailedItems, &job.Error, &job.CreatedAt, &startedAt, &finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	job.Payload = payload
+	job.StartedAt = nullTimePtr(startedAt)
+	job.FinishedAt = nullTimePtr(finishedAt)
+	return &job, nil
+}
@@ -8,10 +8,37 @@ import (
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
+
+	"github.com/lib/pq"
 )
 
+// requiredTagsColumn selects required tags of the PR row aliased as pr
+const requiredTagsColumn = "ARRAY(SELECT tag FROM pr_required_tags WHERE pull_request_id = pr.pull_request_id ORDER BY tag)"
+
+// labelsColumn selects labels of the PR row aliased as pr
+const labelsColumn = "ARRAY(SELECT label FROM pr_labels WHERE pull_request_id = pr.pull_request_id ORDER BY label)"
+
+// dependsOnColumn selects IDs of PRs the PR row aliased as pr depends on
+const dependsOnColumn = "ARRAY(SELECT depends_on_id FROM pr_dependencies " +
+	"WHERE pull_request_id = pr.pull_request_id ORDER BY depends_on_id)"
+
+// shortPRColumns selects the fields of domain.PullRequestShort from the PR row aliased as pr
+const shortPRColumns = "pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.priority, pr.size, " +
+	"pr.created_at, pr.merged_at, " + labelsColumn
+
+// awaitingVerdictCondition holds when the reviewer of the pr_reviewers row aliased as prr has not yet
+// approved or requested changes on the PR aliased as pr (a COMMENTED verdict still awaits a decision).
+// Only verdicts of the current assignment count, see loadReviews
+const awaitingVerdictCondition = `COALESCE((
+	SELECT rv.verdict FROM pr_reviews rv
+	WHERE rv.pull_request_id = pr.pull_request_id AND rv.user_id = prr.user_id
+	  AND rv.submitted_at >= prr.assigned_at
+	ORDER BY rv.submitted_at DESC, rv.review_id DESC
+	LIMIT 1
+), 'COMMENTED') = 'COMMENTED'`
+
 type pullRequestRepository struct {
-	db *sql.DB
+	db executor
 }
 
 // NewPullRequestRepository creates a new PostgreSQL pull request repository
@@ -19,8 +46,8 @@ func NewPullRequestRepository(db *sql.DB) *pullRequestRepository {
 	return &pullRequestRepository{db: db}
 }
 
-func (r *pullRequestRepository) CreatePR(pr *domain.PullRequest) error {
-	tx, err := r.db.Begin()
+func (r *pullRequestRepository) CreatePR(pr *domain.PullRequest, change domain.AssignmentChange) error {
+	tx, err := begin(r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -31,25 +58,38 @@ func (r *pullRequestRepository) CreatePR(pr *domain.PullRequest) error {
 	now := time.Now()
 	// Create PR
 	_, err = tx.Exec(
-		`INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at) 
-		 VALUES ($1, $2, $3, $4, $5)`,
-		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, now,
+		`INSERT INTO pull_requests
+		     (pull_request_id, pull_request_name, author_id, status, created_at, description, priority, size)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.Status, now, pr.Description, pr.Priority, pr.Size,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create PR: %w", err)
 	}
 
 	// Assign reviewers
-	for _, reviewerID := range pr.AssignedReviewers {
+	if err := insertReviewers(tx, pr, pr.AssignedReviewers, change); err != nil {
+		return err
+	}
+
+	if len(pr.RequiredTags) > 0 {
 		_, err = tx.Exec(
-			"INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)",
-			pr.PullRequestID, reviewerID,
+			"INSERT INTO pr_required_tags (pull_request_id, tag) SELECT $1, UNNEST($2::text[])",
+			pr.PullRequestID, pq.Array(pr.RequiredTags),
 		)
 		if err != nil {
-			return fmt.Errorf("failed to assign reviewer %s: %w", reviewerID, err)
+			return fmt.Errorf("failed to store required tags: %w", err)
 		}
 	}
 
+	if err := insertLabels(tx, pr.PullRequestID, pr.Labels); err != nil {
+		return err
+	}
+
+	if err := insertDependencies(tx, pr.PullRequestID, pr.DependsOn); err != nil {
+		return err
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit PR creation: %w", err)
 	}
@@ -59,14 +99,29 @@ func (r *pullRequestRepository) CreatePR(pr *domain.PullRequest) error {
 }
 
 func (r *pullRequestRepository) GetPR(prID string) (*domain.PullRequest, error) {
+	return r.getPR(prID, "")
+}
+
+func (r *pullRequestRepository) GetPRForUpdate(prID string) (*domain.PullRequest, error) {
+	return r.getPR(prID, "FOR UPDATE OF pr")
+}
+
+// getPR reads a PR with its reviewers and reviews, locking the PR row with the lock clause (empty = no lock)
+func (r *pullRequestRepository) getPR(prID string, lock string) (*domain.PullRequest, error) {
 	var pr domain.PullRequest
-	var createdAt, mergedAt sql.NullTime
+	var createdAt, mergedAt, closedAt, archivedAt sql.NullTime
 
 	err := r.db.QueryRow(
-		`SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at 
-		 FROM pull_requests WHERE pull_request_id = $1`,
+		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+		        pr.closed_at, pr.archived_at, pr.force_merged, pr.description, pr.priority, pr.size,
+		        `+requiredTagsColumn+`, `+labelsColumn+`, `+dependsOnColumn+`
+		 FROM pull_requests pr WHERE pr.pull_request_id = $1 `+lock,
 		prID,
-	).Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt)
+	).Scan(
+		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &createdAt, &mergedAt,
+		&closedAt, &archivedAt, &pr.ForceMerged, &pr.Description, &pr.Priority, &pr.Size,
+		pq.Array(&pr.RequiredTags), pq.Array(&pr.Labels), pq.Array(&pr.DependsOn),
+	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, repository.ErrNotFound
@@ -80,34 +135,95 @@ func (r *pullRequestRepository) GetPR(prID string) (*domain.PullRequest, error)
 	if mergedAt.Valid {
 		pr.MergedAt = &mergedAt.Time
 	}
+	if closedAt.Valid {
+		pr.ClosedAt = &closedAt.Time
+	}
+	pr.ArchivedAt = nullTimePtr(archivedAt)
 
-	// Get assigned reviewers
+	if err := r.loadReviewers(&pr); err != nil {
+		return nil, err
+	}
+	if err := r.loadReviews(&pr); err != nil {
+		return nil, err
+	}
+
+	return &pr, nil
+}
+
+// loadReviews fills the latest verdict of each assigned reviewer of the PR. Verdicts submitted before the
+// reviewer's current assignment are stale: a reviewer assigned again, e.g. after the PR was closed and
+// reopened, starts without a verdict
+func (r *pullRequestRepository) loadReviews(pr *domain.PullRequest) error {
 	rows, err := r.db.Query(
-		"SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1 ORDER BY user_id",
-		prID,
+		`SELECT DISTINCT ON (rv.user_id) rv.user_id, rv.verdict, rv.submitted_at
+		 FROM pr_reviews rv
+		 INNER JOIN pr_reviewers prr ON prr.pull_request_id = rv.pull_request_id AND prr.user_id = rv.user_id
+		 WHERE rv.pull_request_id = $1 AND rv.submitted_at >= prr.assigned_at
+		 ORDER BY rv.user_id, rv.submitted_at DESC, rv.review_id DESC`,
+		pr.PullRequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query reviews: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var review domain.ReviewerState
+		if err := rows.Scan(&review.UserID, &review.Verdict, &review.SubmittedAt); err != nil {
+			return fmt.Errorf("failed to scan review: %w", err)
+		}
+		pr.Reviews = append(pr.Reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating reviews: %w", err)
+	}
+
+	return nil
+}
+
+// loadReviewers fills assigned and fallback reviewers of the PR with their selections
+func (r *pullRequestRepository) loadReviewers(pr *domain.PullRequest) error {
+	rows, err := r.db.Query(
+		`SELECT user_id, is_fallback, selection_strategy, selection_seed
+		 FROM pr_reviewers WHERE pull_request_id = $1 ORDER BY user_id`,
+		pr.PullRequestID,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query reviewers: %w", err)
+		return fmt.Errorf("failed to query reviewers: %w", err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var reviewerID string
-		if err := rows.Scan(&reviewerID); err != nil {
-			return nil, fmt.Errorf("failed to scan reviewer: %w", err)
+		var isFallback bool
+		var strategy sql.NullString
+		var seed sql.NullInt64
+		if err := rows.Scan(&reviewerID, &isFallback, &strategy, &seed); err != nil {
+			return fmt.Errorf("failed to scan reviewer: %w", err)
 		}
 		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		if isFallback {
+			pr.FallbackReviewers = append(pr.FallbackReviewers, reviewerID)
+		}
+		if strategy.Valid && seed.Valid {
+			pr.Selections = append(pr.Selections, domain.ReviewerSelection{
+				UserID:   reviewerID,
+				Strategy: domain.ReviewerStrategy(strategy.String),
+				Seed:     seed.Int64,
+			})
+		}
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating reviewers: %w", err)
+		return fmt.Errorf("error iterating reviewers: %w", err)
 	}
 
-	return &pr, nil
+	return nil
 }
 
-func (r *pullRequestRepository) UpdatePR(pr *domain.PullRequest) error {
-	tx, err := r.db.Begin()
+func (r *pullRequestRepository) UpdatePR(pr *domain.PullRequest, change domain.AssignmentChange) error {
+	tx, err := begin(r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -118,54 +234,109 @@ func (r *pullRequestRepository) UpdatePR(pr *domain.PullRequest) error {
 	// Update PR
 	_, err = tx.Exec(
 		`UPDATE pull_requests 
-		 SET pull_request_name = $1, status = $2, merged_at = $3 
-		 WHERE pull_request_id = $4`,
-		pr.PullRequestName, pr.Status, pr.MergedAt, pr.PullRequestID,
+		 SET pull_request_name = $1, status = $2, merged_at = $3, closed_at = $4, force_merged = $5
+		 WHERE pull_request_id = $6`,
+		pr.PullRequestName, pr.Status, pr.MergedAt, pr.ClosedAt, pr.ForceMerged, pr.PullRequestID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update PR: %w", err)
 	}
 
-	// Delete old reviewers
-	_, err = tx.Exec("DELETE FROM pr_reviewers WHERE pull_request_id = $1", pr.PullRequestID)
+	// Only reviewers that changed are touched, so the kept ones retain their assignment time
+	rows, err := tx.Query(
+		"SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1 FOR UPDATE",
+		pr.PullRequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query old reviewers: %w", err)
+	}
+	oldReviewers, err := scanIDs(rows)
+	rows.Close()
 	if err != nil {
-		return fmt.Errorf("failed to delete old reviewers: %w", err)
+		return err
 	}
 
-	// Insert new reviewers
+	var removed, added []string
+	for _, reviewerID := range oldReviewers {
+		if !contains(pr.AssignedReviewers, reviewerID) {
+			removed = append(removed, reviewerID)
+		}
+	}
 	for _, reviewerID := range pr.AssignedReviewers {
+		if !contains(oldReviewers, reviewerID) {
+			added = append(added, reviewerID)
+		}
+	}
+
+	// Delete removed reviewers
+	for _, reviewerID := range removed {
 		_, err = tx.Exec(
-			"INSERT INTO pr_reviewers (pull_request_id, user_id) VALUES ($1, $2)",
+			"DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2",
 			pr.PullRequestID, reviewerID,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to assign reviewer %s: %w", reviewerID, err)
+			return fmt.Errorf("failed to delete reviewer %s: %w", reviewerID, err)
+		}
+
+		err = insertAssignmentEvent(tx, &domain.AssignmentEvent{
+			PullRequestID: pr.PullRequestID,
+			Type:          domain.AssignmentEventUnassign,
+			UserID:        reviewerID,
+			Actor:         change.Actor,
+			Reason:        change.Reason,
+		})
+		if err != nil {
+			return err
 		}
 	}
 
+	// Insert added reviewers
+	if err := insertReviewers(tx, pr, added, change); err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
-func (r *pullRequestRepository) MergePR(prID string) (*domain.PullRequest, error) {
-	pr, err := r.GetPR(prID)
+func (r *pullRequestRepository) MergePR(prID string, force bool, actor string) error {
+	tx, err := begin(r.db)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
 
-	// If already merged, return current state (idempotent)
-	if pr.Status == domain.PRStatusMerged {
-		return pr, nil
+	// Merging keeps the reviewers, so only the PR row is touched
+	result, err := tx.Exec(
+		`UPDATE pull_requests SET status = 'MERGED', merged_at = NOW(), force_merged = $2
+		 WHERE pull_request_id = $1 AND status = 'OPEN'`,
+		prID, force,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to merge PR: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check merged PR: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
 	}
 
-	now := time.Now()
-	pr.Status = domain.PRStatusMerged
-	pr.MergedAt = &now
-
-	if err := r.UpdatePR(pr); err != nil {
-		return nil, err
+	if force {
+		err = insertAssignmentEvent(tx, &domain.AssignmentEvent{
+			PullRequestID: prID,
+			Type:          domain.AssignmentEventForceMerge,
+			Actor:         actor,
+			Reason:        "forced merge",
+		})
+		if err != nil {
+			return err
+		}
 	}
 
-	return pr, nil
+	return tx.Commit()
 }
 
 func (r *pullRequestRepository) PRExists(prID string) (bool, error) {
@@ -177,12 +348,53 @@ func (r *pullRequestRepository) PRExists(prID string) (bool, error) {
 	return exists, err
 }
 
-func (r *pullRequestRepository) GetPRsByReviewer(userID string) ([]*domain.PullRequestShort, error) {
+func (r *pullRequestRepository) DeletePR(prID string) error {
+	result, err := r.db.Exec("DELETE FROM pull_requests WHERE pull_request_id = $1", prID)
+	if err != nil {
+		return fmt.Errorf("failed to delete PR: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check deleted PR: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *pullRequestRepository) ArchiveMergedPRs(mergedBefore time.Time) (int, error) {
+	result, err := r.db.Exec(
+		`UPDATE pull_requests SET archived_at = CURRENT_TIMESTAMP
+		 WHERE status = 'MERGED' AND merged_at < $1 AND archived_at IS NULL`,
+		mergedBefore,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive PRs: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check archived PRs: %w", err)
+	}
+	return int(affected), nil
+}
+
+// archivedCondition holds for the PR row aliased as pr unless it is archived and archived PRs are skipped
+func archivedCondition(includeArchived bool) string {
+	if includeArchived {
+		return "TRUE"
+	}
+	return "pr.archived_at IS NULL"
+}
+
+func (r *pullRequestRepository) GetPRsByReviewer(userID string, includeArchived bool) ([]*domain.PullRequestShort, error) {
 	rows, err := r.db.Query(
-		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		`SELECT `+shortPRColumns+`
 		 FROM pull_requests pr
 		 INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
-		 WHERE prr.user_id = $1
+		 WHERE prr.user_id = $1 AND `+archivedCondition(includeArchived)+`
 		 ORDER BY pr.created_at DESC`,
 		userID,
 	)
@@ -194,7 +406,7 @@ func (r *pullRequestRepository) GetPRsByReviewer(userID string) ([]*domain.PullR
 	var prs []*domain.PullRequestShort
 	for rows.Next() {
 		pr := &domain.PullRequestShort{}
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+		if err := scanShortPR(rows, pr); err != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", err)
 		}
 		prs = append(prs, pr)
@@ -207,8 +419,333 @@ func (r *pullRequestRepository) GetPRsByReviewer(userID string) ([]*domain.PullR
 	return prs, nil
 }
 
-func (r *pullRequestRepository) ReassignReviewer(prID string, oldUserID string, newUserID string) error {
-	tx, err := r.db.Begin()
+func (r *pullRequestRepository) GetPRsAwaitingVerdict(userID string) ([]*domain.PullRequestShort, error) {
+	rows, err := r.db.Query(
+		`SELECT `+shortPRColumns+`
+		 FROM pull_requests pr
+		 INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		 WHERE prr.user_id = $1 AND pr.status = 'OPEN' AND `+awaitingVerdictCondition+`
+		 ORDER BY pr.created_at DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PRs awaiting verdict: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []*domain.PullRequestShort{}
+	for rows.Next() {
+		pr := &domain.PullRequestShort{}
+		if err := scanShortPR(rows, pr); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+// scanShortPR reads a row selected with shortPRColumns into pr
+func scanShortPR(rows *sql.Rows, pr *domain.PullRequestShort) error {
+	var createdAt, mergedAt sql.NullTime
+	if err := rows.Scan(
+		&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status, &pr.Priority, &pr.Size,
+		&createdAt, &mergedAt, pq.Array(&pr.Labels),
+	); err != nil {
+		return err
+	}
+	pr.CreatedAt = nullTimePtr(createdAt)
+	pr.MergedAt = nullTimePtr(mergedAt)
+	return nil
+}
+
+func (r *pullRequestRepository) ListPRs(
+	query domain.PRQuery,
+	after *repository.PRCursor,
+	limit int,
+) ([]*domain.PullRequestShort, error) {
+	var conditions []string
+	var args []interface{}
+	// where adds a condition on the next argument, referenced as $%d in the condition
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if query.Status != "" {
+		where("pr.status = $%d", query.Status)
+	}
+	if query.AuthorID != "" {
+		where("pr.author_id = $%d", query.AuthorID)
+	}
+	if query.ReviewerID != "" {
+		where(`EXISTS (SELECT 1 FROM pr_reviewers prr
+		               WHERE prr.pull_request_id = pr.pull_request_id AND prr.user_id = $%d)`, query.ReviewerID)
+	}
+	if query.TeamName != "" {
+		where("EXISTS (SELECT 1 FROM users u WHERE u.user_id = pr.author_id AND u.team_name = $%d)", query.TeamName)
+	}
+	if query.Label != "" {
+		where(`EXISTS (SELECT 1 FROM pr_labels l
+		               WHERE l.pull_request_id = pr.pull_request_id AND l.label = $%d)`, query.Label)
+	}
+	if query.Priority != "" {
+		where("pr.priority = $%d", query.Priority)
+	}
+	if query.CreatedFrom != nil {
+		where("pr.created_at >= $%d", *query.CreatedFrom)
+	}
+	if query.CreatedTo != nil {
+		where("pr.created_at < $%d", *query.CreatedTo)
+	}
+	if query.MergedFrom != nil {
+		where("pr.merged_at >= $%d", *query.MergedFrom)
+	}
+	if query.MergedTo != nil {
+		where("pr.merged_at < $%d", *query.MergedTo)
+	}
+
+	order, compare := "DESC", "<"
+	if query.Ascending {
+		order, compare = "ASC", ">"
+	}
+	if after != nil {
+		args = append(args, after.CreatedAt, after.PullRequestID)
+		conditions = append(conditions, fmt.Sprintf(
+			"(pr.created_at, pr.pull_request_id) %s ($%d, $%d)", compare, len(args)-1, len(args),
+		))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.Query(fmt.Sprintf(
+		`SELECT `+shortPRColumns+`
+		 FROM pull_requests pr
+		 %s
+		 ORDER BY pr.created_at %s, pr.pull_request_id %s
+		 LIMIT $%d`,
+		whereClause, order, order, len(args),
+	), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query PRs: %w", err)
+	}
+	defer rows.Close()
+
+	prs := []*domain.PullRequestShort{}
+	for rows.Next() {
+		pr := &domain.PullRequestShort{}
+		if err := scanShortPR(rows, pr); err != nil {
+			return nil, fmt.Errorf("failed to scan PR: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating PRs: %w", err)
+	}
+
+	return prs, nil
+}
+
+func (r *pullRequestRepository) UpdatePRMetadata(pr *domain.PullRequest) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	result, err := tx.Exec(
+		`UPDATE pull_requests SET pull_request_name = $1, description = $2, priority = $3, size = $4
+		 WHERE pull_request_id = $5`,
+		pr.PullRequestName, pr.Description, pr.Priority, pr.Size, pr.PullRequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update PR metadata: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check updated PR: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	_, err = tx.Exec("DELETE FROM pr_labels WHERE pull_request_id = $1", pr.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to delete old labels: %w", err)
+	}
+	if err := insertLabels(tx, pr.PullRequestID, pr.Labels); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("DELETE FROM pr_dependencies WHERE pull_request_id = $1", pr.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to delete old dependencies: %w", err)
+	}
+	if err := insertDependencies(tx, pr.PullRequestID, pr.DependsOn); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertLabels stores labels of a PR that has none yet
+func insertLabels(tx executor, prID string, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(
+		"INSERT INTO pr_labels (pull_request_id, label) SELECT $1, UNNEST($2::text[])",
+		prID, pq.Array(labels),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store labels: %w", err)
+	}
+	return nil
+}
+
+// insertDependencies stores dependencies of a PR that has none yet
+func insertDependencies(tx executor, prID string, dependsOn []string) error {
+	if len(dependsOn) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(
+		"INSERT INTO pr_dependencies (pull_request_id, depends_on_id) SELECT $1, UNNEST($2::text[])",
+		prID, pq.Array(dependsOn),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store dependencies: %w", err)
+	}
+	return nil
+}
+
+func (r *pullRequestRepository) GetDependencyClosure(prIDs []string) ([]string, error) {
+	if len(prIDs) == 0 {
+		return []string{}, nil
+	}
+
+	rows, err := r.db.Query(
+		`WITH RECURSIVE closure(pull_request_id) AS (
+		     SELECT depends_on_id FROM pr_dependencies WHERE pull_request_id = ANY($1)
+		     UNION
+		     SELECT d.depends_on_id FROM pr_dependencies d
+		     INNER JOIN closure c ON d.pull_request_id = c.pull_request_id
+		 )
+		 SELECT pull_request_id FROM closure`,
+		pq.Array(prIDs),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependency closure: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIDs(rows)
+}
+
+func (r *pullRequestRepository) GetUnmergedDependencies(prID string) ([]string, error) {
+	rows, err := r.db.Query(
+		`SELECT d.depends_on_id
+		 FROM pr_dependencies d
+		 INNER JOIN pull_requests pr ON pr.pull_request_id = d.depends_on_id
+		 WHERE d.pull_request_id = $1 AND pr.status <> 'MERGED'
+		 ORDER BY d.depends_on_id`,
+		prID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unmerged dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIDs(rows)
+}
+
+func (r *pullRequestRepository) GetDependents(prID string) ([]string, error) {
+	rows, err := r.db.Query(
+		"SELECT pull_request_id FROM pr_dependencies WHERE depends_on_id = $1 ORDER BY pull_request_id",
+		prID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependents: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIDs(rows)
+}
+
+// scanIDs reads single-column ID rows
+func scanIDs(rows *sql.Rows) ([]string, error) {
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *pullRequestRepository) SubmitReview(prID string, userID string, verdict domain.ReviewVerdict) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	// The shared lock waits for a merge in progress, so a merge either sees the verdict or the verdict
+	// sees the merged PR
+	var status domain.PRStatus
+	err = tx.QueryRow(
+		"SELECT status FROM pull_requests WHERE pull_request_id = $1 FOR SHARE",
+		prID,
+	).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock PR: %w", err)
+	}
+	if status != domain.PRStatusOpen {
+		return repository.ErrNotFound
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO pr_reviews (pull_request_id, user_id, verdict) VALUES ($1, $2, $3)",
+		prID, userID, verdict,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *pullRequestRepository) ReassignReviewer(
+	prID string,
+	oldUserID string,
+	replacement domain.ReviewerSelection,
+	isFallback bool,
+	change domain.AssignmentChange,
+) error {
+	tx, err := begin(r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -229,20 +766,315 @@ func (r *pullRequestRepository) ReassignReviewer(prID string, oldUserID string,
 	}
 
 	_, err = tx.Exec(
-		"UPDATE pr_reviewers SET user_id = $1 WHERE pull_request_id = $2 AND user_id = $3",
-		newUserID, prID, oldUserID,
+		`UPDATE pr_reviewers
+		 SET user_id = $1, is_fallback = $2, selection_strategy = $3, selection_seed = $4,
+		     assigned_at = CURRENT_TIMESTAMP, overdue_at = NULL
+		 WHERE pull_request_id = $5 AND user_id = $6`,
+		replacement.UserID, isFallback, replacement.Strategy, replacement.Seed, prID, oldUserID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to reassign reviewer: %w", err)
 	}
 
+	eventType := change.Type
+	if eventType == "" {
+		eventType = domain.AssignmentEventReassign
+	}
+	err = insertAssignmentEvent(tx, &domain.AssignmentEvent{
+		PullRequestID: prID,
+		Type:          eventType,
+		UserID:        oldUserID,
+		ReplacementID: replacement.UserID,
+		Actor:         change.Actor,
+		Reason:        change.Reason,
+		Strategy:      replacement.Strategy,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *pullRequestRepository) AddReviewer(
+	prID string,
+	userID string,
+	isFallback bool,
+	change domain.AssignmentChange,
+) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	result, err := tx.Exec(
+		`INSERT INTO pr_reviewers (pull_request_id, user_id, is_fallback) VALUES ($1, $2, $3)
+		 ON CONFLICT (pull_request_id, user_id) DO NOTHING`,
+		prID, userID, isFallback,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add reviewer: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check added reviewer: %w", err)
+	}
+	// An already assigned reviewer is not assigned again
+	if affected == 0 {
+		return nil
+	}
+
+	err = insertAssignmentEvent(tx, &domain.AssignmentEvent{
+		PullRequestID: prID,
+		Type:          domain.AssignmentEventAssign,
+		UserID:        userID,
+		Actor:         change.Actor,
+		Reason:        change.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
 	return tx.Commit()
 }
 
-func (r *pullRequestRepository) GetStats() (*domain.Stats, error) {
+func (r *pullRequestRepository) RemoveReviewer(prID string, userID string, change domain.AssignmentChange) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	result, err := tx.Exec(
+		"DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2",
+		prID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check removed reviewer: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	err = insertAssignmentEvent(tx, &domain.AssignmentEvent{
+		PullRequestID: prID,
+		Type:          domain.AssignmentEventUnassign,
+		UserID:        userID,
+		Actor:         change.Actor,
+		Reason:        change.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *pullRequestRepository) DeclineReview(
+	prID string,
+	userID string,
+	reason string,
+	replacement *domain.ReviewerSelection,
+	isFallback bool,
+) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	var replacedBy sql.NullString
+	var result sql.Result
+	if replacement != nil {
+		replacedBy = sql.NullString{String: replacement.UserID, Valid: true}
+		result, err = tx.Exec(
+			`UPDATE pr_reviewers
+			 SET user_id = $1, is_fallback = $2, selection_strategy = $3, selection_seed = $4,
+			     assigned_at = CURRENT_TIMESTAMP, overdue_at = NULL
+			 WHERE pull_request_id = $5 AND user_id = $6`,
+			replacement.UserID, isFallback, replacement.Strategy, replacement.Seed, prID, userID,
+		)
+	} else {
+		result, err = tx.Exec(
+			"DELETE FROM pr_reviewers WHERE pull_request_id = $1 AND user_id = $2",
+			prID, userID,
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to unassign reviewer: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check unassigned reviewer: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO pr_declines (pull_request_id, user_id, reason, replaced_by) VALUES ($1, $2, $3, $4)",
+		prID, userID, reason, replacedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record decline: %w", err)
+	}
+
+	event := &domain.AssignmentEvent{
+		PullRequestID: prID,
+		Type:          domain.AssignmentEventUnassign,
+		UserID:        userID,
+		Actor:         userID,
+		Reason:        "declined: " + reason,
+	}
+	if replacement != nil {
+		event.Type = domain.AssignmentEventReassign
+		event.ReplacementID = replacement.UserID
+		event.Strategy = replacement.Strategy
+	}
+	if err := insertAssignmentEvent(tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *pullRequestRepository) GetAssignmentHistory(prID string) ([]*domain.AssignmentEvent, error) {
+	rows, err := r.db.Query(
+		`SELECT event_id, pull_request_id, event_type, user_id, replacement_id, actor, reason, strategy, created_at
+		 FROM assignment_events
+		 WHERE pull_request_id = $1
+		 ORDER BY event_id`,
+		prID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query assignment history: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*domain.AssignmentEvent{}
+	for rows.Next() {
+		var event domain.AssignmentEvent
+		var userID, replacementID, strategy sql.NullString
+		if err := rows.Scan(
+			&event.EventID, &event.PullRequestID, &event.Type, &userID, &replacementID,
+			&event.Actor, &event.Reason, &strategy, &event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan assignment event: %w", err)
+		}
+		event.UserID = userID.String
+		event.ReplacementID = replacementID.String
+		event.Strategy = domain.ReviewerStrategy(strategy.String)
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignment history: %w", err)
+	}
+
+	return events, nil
+}
+
+// insertAssignmentEvent appends an event to the assignment history of its PR
+func insertAssignmentEvent(tx executor, event *domain.AssignmentEvent) error {
+	_, err := tx.Exec(
+		`INSERT INTO assignment_events
+		     (pull_request_id, event_type, user_id, replacement_id, actor, reason, strategy)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		event.PullRequestID, event.Type,
+		sql.NullString{String: event.UserID, Valid: event.UserID != ""},
+		sql.NullString{String: event.ReplacementID, Valid: event.ReplacementID != ""},
+		event.Actor, event.Reason,
+		sql.NullString{String: string(event.Strategy), Valid: event.Strategy != ""},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record assignment event: %w", err)
+	}
+	return nil
+}
+
+func (r *pullRequestRepository) MarkOverdueAssignments(now time.Time) ([]*domain.OverdueAssignment, error) {
+	rows, err := r.db.Query(
+		`UPDATE pr_reviewers prr
+		 SET overdue_at = $1
+		 FROM pull_requests pr
+		 INNER JOIN users a ON a.user_id = pr.author_id
+		 INNER JOIN teams t ON t.team_name = a.team_name
+		 WHERE prr.pull_request_id = pr.pull_request_id
+		   AND pr.status = 'OPEN' AND prr.overdue_at IS NULL AND t.review_sla_hours > 0
+		   AND prr.assigned_at + make_interval(hours => t.review_sla_hours) <= $1
+		   AND `+awaitingVerdictCondition+`
+		 RETURNING pr.pull_request_id, pr.pull_request_name, pr.author_id, prr.user_id, a.team_name,
+		           prr.assigned_at, prr.overdue_at`,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark overdue assignments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanOverdueAssignments(rows)
+}
+
+func (r *pullRequestRepository) GetOverdueAssignments(teamName string) ([]*domain.OverdueAssignment, error) {
+	rows, err := r.db.Query(
+		`SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, prr.user_id, a.team_name,
+		        prr.assigned_at, prr.overdue_at
+		 FROM pr_reviewers prr
+		 INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		 INNER JOIN users a ON a.user_id = pr.author_id
+		 WHERE prr.overdue_at IS NOT NULL AND pr.status = 'OPEN'
+		   AND ($1 = '' OR a.team_name = $1)
+		   AND `+awaitingVerdictCondition+`
+		 ORDER BY prr.overdue_at, pr.pull_request_id, prr.user_id`,
+		teamName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query overdue assignments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanOverdueAssignments(rows)
+}
+
+// scanOverdueAssignments reads overdue assignments from rows
+func scanOverdueAssignments(rows *sql.Rows) ([]*domain.OverdueAssignment, error) {
+	assignments := []*domain.OverdueAssignment{}
+	for rows.Next() {
+		assignment := &domain.OverdueAssignment{}
+		if err := rows.Scan(
+			&assignment.PullRequestID, &assignment.PullRequestName, &assignment.AuthorID, &assignment.UserID,
+			&assignment.TeamName, &assignment.AssignedAt, &assignment.OverdueAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue assignment: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating overdue assignments: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func (r *pullRequestRepository) GetStats(includeArchived bool) (*domain.Stats, error) {
 	stats := &domain.Stats{}
+	archived := archivedCondition(includeArchived)
 
-	err := r.db.QueryRow("SELECT COUNT(*) FROM pull_requests").Scan(&stats.TotalPRs)
+	err := r.db.QueryRow("SELECT COUNT(*) FROM pull_requests pr WHERE " + archived).Scan(&stats.TotalPRs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get total PRs: %w", err)
 	}
@@ -256,9 +1088,11 @@ func (r *pullRequestRepository) GetStats() (*domain.Stats, error) {
 	err = r.db.QueryRow(`
 		SELECT COALESCE(AVG(reviewer_count), 0) 
 		FROM (
-			SELECT pull_request_id, COUNT(*) as reviewer_count 
-			FROM pr_reviewers 
-			GROUP BY pull_request_id
+			SELECT prr.pull_request_id, COUNT(*) as reviewer_count 
+			FROM pr_reviewers prr
+			INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+			WHERE ` + archived + `
+			GROUP BY prr.pull_request_id
 		) subq
 	`).Scan(&avgReviewers)
 	if err != nil {
@@ -269,10 +1103,27 @@ func (r *pullRequestRepository) GetStats() (*domain.Stats, error) {
 	}
 
 	rows, err := r.db.Query(`
-		SELECT u.user_id, u.username, COUNT(prr.user_id) as assignment_count
+		SELECT u.user_id, u.username, COUNT(prr.user_id) as assignment_count, COALESCE(d.decline_count, 0),
+		       COALESCE(ra.reassigned_count, 0)
 		FROM users u
-		LEFT JOIN pr_reviewers prr ON u.user_id = prr.user_id
-		GROUP BY u.user_id, u.username
+		LEFT JOIN (
+			pr_reviewers prr INNER JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id AND ` + archived + `
+		) ON u.user_id = prr.user_id
+		LEFT JOIN (
+			SELECT pd.user_id, COUNT(*) as decline_count
+			FROM pr_declines pd
+			INNER JOIN pull_requests pr ON pr.pull_request_id = pd.pull_request_id
+			WHERE ` + archived + `
+			GROUP BY pd.user_id
+		) d ON u.user_id = d.user_id
+		LEFT JOIN (
+			SELECT ae.user_id, COUNT(*) as reassigned_count
+			FROM assignment_events ae
+			INNER JOIN pull_requests pr ON pr.pull_request_id = ae.pull_request_id
+			WHERE ae.event_type IN ('reassign', 'bulk_deactivate') AND ` + archived + `
+			GROUP BY ae.user_id
+		) ra ON u.user_id = ra.user_id
+		GROUP BY u.user_id, u.username, d.decline_count, ra.reassigned_count
 		ORDER BY assignment_count DESC
 	`)
 	if err != nil {
@@ -282,7 +1133,10 @@ func (r *pullRequestRepository) GetStats() (*domain.Stats, error) {
 
 	for rows.Next() {
 		var userStat domain.UserAssignmentStats
-		if scanErr := rows.Scan(&userStat.UserID, &userStat.Username, &userStat.AssignmentCount); scanErr != nil {
+		if scanErr := rows.Scan(
+			&userStat.UserID, &userStat.Username, &userStat.AssignmentCount, &userStat.DeclineCount,
+			&userStat.ReassignedAwayCount,
+		); scanErr != nil {
 			return nil, fmt.Errorf("failed to scan user stats: %w", scanErr)
 		}
 		stats.AssignmentsByUser = append(stats.AssignmentsByUser, userStat)
@@ -295,6 +1149,7 @@ func (r *pullRequestRepository) GetStats() (*domain.Stats, error) {
 		SELECT pr.pull_request_id, pr.pull_request_name, COUNT(prr.user_id) as reviewer_count
 		FROM pull_requests pr
 		LEFT JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		WHERE ` + archived + `
 		GROUP BY pr.pull_request_id, pr.pull_request_name
 		ORDER BY reviewer_count DESC
 	`)
@@ -314,6 +1169,34 @@ func (r *pullRequestRepository) GetStats() (*domain.Stats, error) {
 		return nil, fmt.Errorf("error iterating PR stats: %w", err)
 	}
 
+	pairRows, err := r.db.Query(`
+		SELECT pr.author_id, prr.user_id, COUNT(*)
+		FROM pull_requests pr
+		INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		WHERE ` + archived + `
+		GROUP BY pr.author_id, prr.user_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pairing matrix: %w", err)
+	}
+	defer pairRows.Close()
+
+	stats.PairingMatrix = make(map[string]map[string]int)
+	for pairRows.Next() {
+		var authorID, reviewerID string
+		var count int
+		if err := pairRows.Scan(&authorID, &reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan pairing: %w", err)
+		}
+		if stats.PairingMatrix[authorID] == nil {
+			stats.PairingMatrix[authorID] = make(map[string]int)
+		}
+		stats.PairingMatrix[authorID][reviewerID] = count
+	}
+	if err := pairRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pairings: %w", err)
+	}
+
 	return stats, nil
 }
 
@@ -330,11 +1213,12 @@ func (r *pullRequestRepository) GetOpenPRsByReviewers(userIDs []string) ([]*doma
 	}
 
 	query := fmt.Sprintf(`
-		SELECT DISTINCT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		SELECT DISTINCT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at,
+		       pr.priority, pr.size, `+requiredTagsColumn+`, `+dependsOnColumn+`
 		FROM pull_requests pr
 		INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
 		WHERE pr.status = 'OPEN' AND prr.user_id IN (%s)
-		ORDER BY pr.created_at DESC
+		ORDER BY pr.created_at DESC, pr.pull_request_id
 	`, strings.Join(placeholders, ", "))
 
 	rows, err := r.db.Query(query, args...)
@@ -357,6 +1241,10 @@ func (r *pullRequestRepository) GetOpenPRsByReviewers(userIDs []string) ([]*doma
 			&pr.Status,
 			&createdAt,
 			&mergedAt,
+			&pr.Priority,
+			&pr.Size,
+			pq.Array(&pr.RequiredTags),
+			pq.Array(&pr.DependsOn),
 		); scanErr != nil {
 			return nil, fmt.Errorf("failed to scan PR: %w", scanErr)
 		}
@@ -368,8 +1256,10 @@ func (r *pullRequestRepository) GetOpenPRsByReviewers(userIDs []string) ([]*doma
 			pr.MergedAt = &mergedAt.Time
 		}
 
+		// PRs are kept in the order of the query
 		if _, exists := prMap[pr.PullRequestID]; !exists {
 			prMap[pr.PullRequestID] = &pr
+			prs = append(prs, &pr)
 		}
 	}
 
@@ -377,29 +1267,120 @@ func (r *pullRequestRepository) GetOpenPRsByReviewers(userIDs []string) ([]*doma
 		return nil, fmt.Errorf("error iterating PRs: %w", err)
 	}
 
-	for _, pr := range prMap {
-		prs = append(prs, pr)
+	for _, pr := range prs {
+		if err := r.loadReviewers(pr); err != nil {
+			return nil, fmt.Errorf("failed to load reviewers for PR %s: %w", pr.PullRequestID, err)
+		}
 	}
 
-	for _, pr := range prs {
-		reviewerRows, err := r.db.Query(
-			"SELECT user_id FROM pr_reviewers WHERE pull_request_id = $1 ORDER BY user_id",
-			pr.PullRequestID,
+	return prs, nil
+}
+
+func (r *pullRequestRepository) GetOpenReviewCountsByTeam(teamName string) (map[string]int, error) {
+	rows, err := r.db.Query(`
+		SELECT u.user_id, COUNT(pr.pull_request_id) AS open_reviews
+		FROM users u
+		LEFT JOIN pr_reviewers prr ON u.user_id = prr.user_id
+		LEFT JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id AND pr.status = 'OPEN'
+		WHERE u.team_name = $1
+		GROUP BY u.user_id
+	`, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open review counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan open review count: %w", err)
+		}
+		counts[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open review counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *pullRequestRepository) GetRecentPairings(authorID string, since time.Time) (map[string]int, error) {
+	rows, err := r.db.Query(`
+		SELECT prr.user_id, COUNT(*)
+		FROM pull_requests pr
+		INNER JOIN pr_reviewers prr ON pr.pull_request_id = prr.pull_request_id
+		WHERE pr.author_id = $1 AND pr.created_at >= $2
+		GROUP BY prr.user_id
+	`, authorID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent pairings: %w", err)
+	}
+	defer rows.Close()
+
+	pairings := make(map[string]int)
+	for rows.Next() {
+		var userID string
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan recent pairing: %w", err)
+		}
+		pairings[userID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recent pairings: %w", err)
+	}
+
+	return pairings, nil
+}
+
+// insertReviewers stores the given reviewers of the PR with their fallback flags and selections
+// and records an assign event for each of them
+func insertReviewers(tx executor, pr *domain.PullRequest, reviewerIDs []string, change domain.AssignmentChange) error {
+	for _, reviewerID := range reviewerIDs {
+		var strategy sql.NullString
+		var seed sql.NullInt64
+		for _, selection := range pr.Selections {
+			if selection.UserID == reviewerID {
+				strategy = sql.NullString{String: string(selection.Strategy), Valid: true}
+				seed = sql.NullInt64{Int64: selection.Seed, Valid: true}
+				break
+			}
+		}
+
+		_, err := tx.Exec(
+			`INSERT INTO pr_reviewers (pull_request_id, user_id, is_fallback, selection_strategy, selection_seed)
+			 VALUES ($1, $2, $3, $4, $5)`,
+			pr.PullRequestID, reviewerID, contains(pr.FallbackReviewers, reviewerID), strategy, seed,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to query reviewers for PR %s: %w", pr.PullRequestID, err)
+			return fmt.Errorf("failed to assign reviewer %s: %w", reviewerID, err)
 		}
 
-		for reviewerRows.Next() {
-			var reviewerID string
-			if err := reviewerRows.Scan(&reviewerID); err != nil {
-				reviewerRows.Close()
-				return nil, fmt.Errorf("failed to scan reviewer: %w", err)
-			}
-			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		err = insertAssignmentEvent(tx, &domain.AssignmentEvent{
+			PullRequestID: pr.PullRequestID,
+			Type:          domain.AssignmentEventAssign,
+			UserID:        reviewerID,
+			Actor:         change.Actor,
+			Reason:        change.Reason,
+			Strategy:      domain.ReviewerStrategy(strategy.String),
+		})
+		if err != nil {
+			return err
 		}
-		reviewerRows.Close()
 	}
+	return nil
+}
 
-	return prs, nil
+// contains reports whether list holds value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,289 @@
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestPR creates team backend with users u1-u4 and an OPEN PR pr-1 of u1 reviewed by the reviewers
+func createTestPR(t *testing.T, db *sql.DB, reviewers ...string) *domain.PullRequest {
+	err := NewTeamRepository(db).CreateTeam(&domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+			{UserID: "u4", Username: "Dave", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Add search",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		Priority:          domain.PRPriorityNormal,
+		AssignedReviewers: reviewers,
+	}
+	require.NoError(t, NewPullRequestRepository(db).CreatePR(pr, domain.AssignmentChange{Actor: "test"}))
+	return pr
+}
+
+func TestPullRequestRepository_ReviewsOfPreviousAssignmentAreStale(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	pr := createTestPR(t, db, "u2")
+
+	require.NoError(t, repo.SubmitReview("pr-1", "u2", domain.ReviewVerdictApproved))
+	got, err := repo.GetPR("pr-1")
+	require.NoError(t, err)
+	require.Len(t, got.Reviews, 1)
+
+	// Closing releases u2; after reopen u2 is assigned again and has to review anew
+	pr.Status = domain.PRStatusClosed
+	pr.AssignedReviewers = nil
+	require.NoError(t, repo.UpdatePR(pr, domain.AssignmentChange{Actor: "test"}))
+	pr.Status = domain.PRStatusOpen
+	pr.AssignedReviewers = []string{"u2"}
+	require.NoError(t, repo.UpdatePR(pr, domain.AssignmentChange{Actor: "test"}))
+
+	got, err = repo.GetPR("pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, got.Reviews)
+
+	awaiting, err := repo.GetPRsAwaitingVerdict("u2")
+	require.NoError(t, err)
+	require.Len(t, awaiting, 1)
+	assert.Equal(t, "pr-1", awaiting[0].PullRequestID)
+}
+
+func TestPullRequestRepository_DeletePRWithDependentsIsRestricted(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	createTestPR(t, db)
+	require.NoError(t, repo.CreatePR(&domain.PullRequest{
+		PullRequestID:   "pr-2",
+		PullRequestName: "Search filters",
+		AuthorID:        "u1",
+		Status:          domain.PRStatusOpen,
+		Priority:        domain.PRPriorityNormal,
+		DependsOn:       []string{"pr-1"},
+	}, domain.AssignmentChange{Actor: "test"}))
+
+	dependents, err := repo.GetDependents("pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-2"}, dependents)
+
+	// Deleting pr-1 would unblock the merge of pr-2
+	assert.Error(t, repo.DeletePR("pr-1"))
+
+	require.NoError(t, repo.DeletePR("pr-2"))
+	assert.NoError(t, repo.DeletePR("pr-1"))
+}
+
+func TestPullRequestRepository_HistoryOutlivesDeletedPR(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	createTestPR(t, db, "u2")
+	require.NoError(t, repo.DeletePR("pr-1"))
+
+	history, err := repo.GetAssignmentHistory("pr-1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.AssignmentEventAssign, history[0].Type)
+	assert.Equal(t, "u2", history[0].UserID)
+}
+
+func TestPullRequestRepository_MergePRKeepsReviewersAndRecordsForce(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	createTestPR(t, db, "u2", "u3")
+
+	require.NoError(t, repo.MergePR("pr-1", true, "admin"))
+
+	got, err := repo.GetPR("pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.PRStatusMerged, got.Status)
+	assert.True(t, got.ForceMerged)
+	assert.NotNil(t, got.MergedAt)
+	assert.Equal(t, []string{"u2", "u3"}, got.AssignedReviewers)
+
+	history, err := repo.GetAssignmentHistory("pr-1")
+	require.NoError(t, err)
+	last := history[len(history)-1]
+	assert.Equal(t, domain.AssignmentEventForceMerge, last.Type)
+	assert.Equal(t, "admin", last.Actor)
+	assert.Empty(t, last.UserID)
+
+	// Only an OPEN PR is merged, and verdicts are no longer accepted
+	assert.ErrorIs(t, repo.MergePR("pr-1", false, "admin"), repository.ErrNotFound)
+	assert.ErrorIs(t, repo.SubmitReview("pr-1", "u2", domain.ReviewVerdictApproved), repository.ErrNotFound)
+}
+
+// createOpenPR creates an OPEN PR of u1 created at createdAt and reviewed by the reviewers
+func createOpenPR(t *testing.T, db *sql.DB, prID string, createdAt time.Time, reviewers ...string) {
+	require.NoError(t, NewPullRequestRepository(db).CreatePR(&domain.PullRequest{
+		PullRequestID:     prID,
+		PullRequestName:   "PR " + prID,
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		Priority:          domain.PRPriorityNormal,
+		AssignedReviewers: reviewers,
+	}, domain.AssignmentChange{Actor: "test"}))
+	setPRCreatedAt(t, db, prID, createdAt)
+}
+
+func setPRCreatedAt(t *testing.T, db *sql.DB, prID string, createdAt time.Time) {
+	_, err := db.Exec("UPDATE pull_requests SET created_at = $1 WHERE pull_request_id = $2", createdAt, prID)
+	require.NoError(t, err)
+}
+
+func TestPullRequestRepository_GetOpenPRsByReviewers(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	createTestPR(t, db, "u2")
+	setPRCreatedAt(t, db, "pr-1", createdAt)
+	createOpenPR(t, db, "pr-2", createdAt.Add(time.Hour), "u3", "u4")
+	createOpenPR(t, db, "pr-3", createdAt.Add(2*time.Hour), "u4")
+	createOpenPR(t, db, "pr-4", createdAt.Add(3*time.Hour), "u2")
+	_, err := db.Exec("UPDATE pull_requests SET status = 'MERGED' WHERE pull_request_id = 'pr-4'")
+	require.NoError(t, err)
+
+	prs, err := repo.GetOpenPRsByReviewers([]string{"u2", "u3"})
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	// Newest first; merged PRs and PRs of other reviewers are left out
+	assert.Equal(t, "pr-2", prs[0].PullRequestID)
+	assert.Equal(t, domain.PRPriorityNormal, prs[0].Priority)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, prs[0].AssignedReviewers)
+	assert.Equal(t, "pr-1", prs[1].PullRequestID)
+	assert.Equal(t, []string{"u2"}, prs[1].AssignedReviewers)
+
+	prs, err = repo.GetOpenPRsByReviewers(nil)
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+}
+
+func TestPullRequestRepository_MarkOverdueAssignments(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	createTestPR(t, db, "u2", "u3")
+	require.NoError(t, repo.SubmitReview("pr-1", "u3", domain.ReviewVerdictApproved))
+
+	now := time.Now().UTC().Add(2 * time.Hour)
+	// Without an SLA no assignment is overdue
+	overdue, err := repo.MarkOverdueAssignments(now)
+	require.NoError(t, err)
+	assert.Empty(t, overdue)
+
+	teamRepo := NewTeamRepository(db)
+	settings, err := teamRepo.GetTeamSettings("backend")
+	require.NoError(t, err)
+	settings.ReviewSLAHours = 1
+	require.NoError(t, teamRepo.UpdateTeamSettings(settings))
+
+	// u3 has already submitted a verdict, so only u2 is overdue
+	overdue, err = repo.MarkOverdueAssignments(now)
+	require.NoError(t, err)
+	require.Len(t, overdue, 1)
+	assert.Equal(t, "pr-1", overdue[0].PullRequestID)
+	assert.Equal(t, "u2", overdue[0].UserID)
+	assert.Equal(t, "backend", overdue[0].TeamName)
+
+	// An assignment is marked once
+	overdue, err = repo.MarkOverdueAssignments(now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, overdue)
+}
+
+func TestPullRequestRepository_ListPRsPagesByCursor(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	repo := NewPullRequestRepository(db)
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	createTestPR(t, db)
+	setPRCreatedAt(t, db, "pr-1", createdAt)
+	// pr-2 and pr-3 share a creation time and are ordered by ID
+	createOpenPR(t, db, "pr-2", createdAt.Add(time.Hour))
+	createOpenPR(t, db, "pr-3", createdAt.Add(time.Hour))
+
+	ids := func(prs []*domain.PullRequestShort) []string {
+		result := make([]string, len(prs))
+		for i, pr := range prs {
+			result[i] = pr.PullRequestID
+		}
+		return result
+	}
+
+	page, err := repo.ListPRs(domain.PRQuery{}, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-3", "pr-2"}, ids(page))
+
+	page, err = repo.ListPRs(domain.PRQuery{}, &repository.PRCursor{
+		CreatedAt:     createdAt.Add(time.Hour),
+		PullRequestID: "pr-2",
+	}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1"}, ids(page))
+
+	page, err = repo.ListPRs(domain.PRQuery{Ascending: true}, nil, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, ids(page))
+
+	page, err = repo.ListPRs(domain.PRQuery{Ascending: true}, &repository.PRCursor{
+		CreatedAt:     createdAt.Add(time.Hour),
+		PullRequestID: "pr-2",
+	}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pr-3"}, ids(page))
+}
@@ -6,10 +6,12 @@ import (
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
+
+	"github.com/lib/pq"
 )
 
 type teamRepository struct {
-	db *sql.DB
+	db executor
 }
 
 // NewTeamRepository creates a new PostgreSQL team repository
@@ -18,7 +20,7 @@ func NewTeamRepository(db *sql.DB) *teamRepository {
 }
 
 func (r *teamRepository) CreateTeam(team *domain.Team) error {
-	tx, err := r.db.Begin()
+	tx, err := begin(r.db)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -26,10 +28,15 @@ func (r *teamRepository) CreateTeam(team *domain.Team) error {
 		_ = tx.Rollback() // Ignore error - transaction may already be committed
 	}()
 
+	strategy := team.ReviewerStrategy
+	if strategy == "" {
+		strategy = domain.ReviewerStrategyRandom
+	}
+
 	// Create team
 	_, err = tx.Exec(
-		"INSERT INTO teams (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING",
-		team.TeamName,
+		"INSERT INTO teams (team_name, reviewer_strategy) VALUES ($1, $2) ON CONFLICT (team_name) DO NOTHING",
+		team.TeamName, strategy,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create team: %w", err)
@@ -38,37 +45,38 @@ func (r *teamRepository) CreateTeam(team *domain.Team) error {
 	// Create or update users
 	for _, member := range team.Members {
 		_, err = tx.Exec(
-			`INSERT INTO users (user_id, username, team_name, is_active) 
-			 VALUES ($1, $2, $3, $4)
+			`INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews) 
+			 VALUES ($1, $2, $3, $4, $5)
 			 ON CONFLICT (user_id) 
-			 DO UPDATE SET username = $2, team_name = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP`,
-			member.UserID, member.Username, team.TeamName, member.IsActive,
+			 DO UPDATE SET username = $2, team_name = $3, is_active = $4, max_open_reviews = $5,
+			               updated_at = CURRENT_TIMESTAMP`,
+			member.UserID, member.Username, team.TeamName, member.IsActive, member.MaxOpenReviews,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create/update user %s: %w", member.UserID, err)
 		}
+
+		// Keep existing tags unless the member lists them explicitly
+		if member.Tags != nil {
+			if err := replaceUserTags(tx, member.UserID, member.Tags); err != nil {
+				return fmt.Errorf("failed to set tags of user %s: %w", member.UserID, err)
+			}
+		}
 	}
 
 	return tx.Commit()
 }
 
 func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
-	// Check if team exists
-	var exists bool
-	err := r.db.QueryRow(
-		"SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)",
-		teamName,
-	).Scan(&exists)
+	settings, err := r.GetTeamSettings(teamName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check team existence: %w", err)
-	}
-	if !exists {
-		return nil, repository.ErrNotFound
+		return nil, err
 	}
 
 	// Get team members
 	rows, err := r.db.Query(
-		"SELECT user_id, username, is_active FROM users WHERE team_name = $1 ORDER BY user_id",
+		`SELECT u.user_id, u.username, u.is_active, u.max_open_reviews, `+userTagsColumn+`
+		 FROM users u WHERE u.team_name = $1 ORDER BY u.user_id`,
 		teamName,
 	)
 	if err != nil {
@@ -79,9 +87,13 @@ func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
 	var members []domain.TeamMember
 	for rows.Next() {
 		var member domain.TeamMember
-		if err := rows.Scan(&member.UserID, &member.Username, &member.IsActive); err != nil {
+		var maxOpenReviews sql.NullInt64
+		if err := rows.Scan(
+			&member.UserID, &member.Username, &member.IsActive, &maxOpenReviews, pq.Array(&member.Tags),
+		); err != nil {
 			return nil, fmt.Errorf("failed to scan team member: %w", err)
 		}
+		member.MaxOpenReviews = nullIntPtr(maxOpenReviews)
 		members = append(members, member)
 	}
 
@@ -90,8 +102,9 @@ func (r *teamRepository) GetTeam(teamName string) (*domain.Team, error) {
 	}
 
 	return &domain.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:         teamName,
+		ReviewerStrategy: settings.ReviewerStrategy,
+		Members:          members,
 	}, nil
 }
 
@@ -103,3 +116,136 @@ func (r *teamRepository) TeamExists(teamName string) (bool, error) {
 	).Scan(&exists)
 	return exists, err
 }
+
+func (r *teamRepository) GetTeamSettings(teamName string) (*domain.TeamSettings, error) {
+	settings := domain.TeamSettings{TeamName: teamName}
+	var defaultMaxOpenReviews sql.NullInt64
+	var overflowTeam sql.NullString
+
+	err := r.db.QueryRow(
+		`SELECT reviewer_strategy, default_max_open_reviews, capacity_overflow, overflow_team,
+		        reviewer_count, min_reviewer_count, max_reviewer_count, affinity_window_days,
+		        required_approvals, review_sla_hours, sla_reassign, large_pr_lines
+		 FROM teams WHERE team_name = $1`,
+		teamName,
+	).Scan(
+		&settings.ReviewerStrategy, &defaultMaxOpenReviews, &settings.CapacityOverflow, &overflowTeam,
+		&settings.ReviewerCount, &settings.MinReviewerCount, &settings.MaxReviewerCount,
+		&settings.AffinityWindowDays, &settings.RequiredApprovals, &settings.ReviewSLAHours, &settings.SLAReassign,
+		&settings.LargePRLines,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+
+	settings.DefaultMaxOpenReviews = nullIntPtr(defaultMaxOpenReviews)
+	settings.OverflowTeam = overflowTeam.String
+
+	rows, err := r.db.Query(
+		"SELECT fallback_team FROM team_fallbacks WHERE team_name = $1 ORDER BY position",
+		teamName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query fallback teams: %w", err)
+	}
+	defer rows.Close()
+
+	settings.FallbackTeams = []string{}
+	for rows.Next() {
+		var fallbackTeam string
+		if err := rows.Scan(&fallbackTeam); err != nil {
+			return nil, fmt.Errorf("failed to scan fallback team: %w", err)
+		}
+		settings.FallbackTeams = append(settings.FallbackTeams, fallbackTeam)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating fallback teams: %w", err)
+	}
+
+	return &settings, nil
+}
+
+func (r *teamRepository) UpdateTeamSettings(settings *domain.TeamSettings) error {
+	tx, err := begin(r.db)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	result, err := tx.Exec(
+		`UPDATE teams
+		 SET reviewer_strategy = $1, default_max_open_reviews = $2, capacity_overflow = $3, overflow_team = $4,
+		     reviewer_count = $5, min_reviewer_count = $6, max_reviewer_count = $7, affinity_window_days = $8,
+		     required_approvals = $9, review_sla_hours = $10, sla_reassign = $11,
+		     large_pr_lines = $12
+		 WHERE team_name = $13`,
+		settings.ReviewerStrategy, settings.DefaultMaxOpenReviews, settings.CapacityOverflow,
+		sql.NullString{String: settings.OverflowTeam, Valid: settings.OverflowTeam != ""},
+		settings.ReviewerCount, settings.MinReviewerCount, settings.MaxReviewerCount, settings.AffinityWindowDays,
+		settings.RequiredApprovals, settings.ReviewSLAHours, settings.SLAReassign,
+		settings.LargePRLines, settings.TeamName,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update team settings: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check updated team: %w", err)
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	// Replace fallback chain
+	_, err = tx.Exec("DELETE FROM team_fallbacks WHERE team_name = $1", settings.TeamName)
+	if err != nil {
+		return fmt.Errorf("failed to delete old fallback teams: %w", err)
+	}
+
+	for position, fallbackTeam := range settings.FallbackTeams {
+		_, err = tx.Exec(
+			"INSERT INTO team_fallbacks (team_name, fallback_team, position) VALUES ($1, $2, $3)",
+			settings.TeamName, fallbackTeam, position,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to add fallback team %s: %w", fallbackTeam, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *teamRepository) GetCodeOwners(teamName string) (string, error) {
+	var content string
+	err := r.db.QueryRow(
+		"SELECT content FROM team_code_owners WHERE team_name = $1",
+		teamName,
+	).Scan(&content)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", repository.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get code owners: %w", err)
+	}
+	return content, nil
+}
+
+func (r *teamRepository) SetCodeOwners(teamName string, content string) error {
+	_, err := r.db.Exec(
+		`INSERT INTO team_code_owners (team_name, content) VALUES ($1, $2)
+		 ON CONFLICT (team_name)
+		 DO UPDATE SET content = $2, updated_at = CURRENT_TIMESTAMP`,
+		teamName, content,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set code owners: %w", err)
+	}
+	return nil
+}
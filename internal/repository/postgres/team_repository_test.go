@@ -45,7 +45,7 @@ func cleanupTestDB(t *testing.T, db *sql.DB) {
 	if db == nil {
 		return
 	}
-	tables := []string{"pr_reviewers", "pull_requests", "users", "teams", "schema_migrations"}
+	tables := []string{"assignment_events", "pr_reviewers", "pull_requests", "users", "teams", "schema_migrations"}
 	for _, table := range tables {
 		_, err := db.Exec("TRUNCATE TABLE " + table + " CASCADE")
 		if err != nil {
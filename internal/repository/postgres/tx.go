@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+
+	"avito-tech-internship/internal/repository"
+)
+
+// executor is the part of *sql.DB and *sql.Tx the repositories query with, so that a repository
+// works the same on the connection pool and bound to a transaction
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// txHandle is the transaction of a repository method: a new one on the connection pool, or the
+// transaction the repository is bound to. A joined transaction is committed or rolled back only by
+// whoever began it, so a failed method leaves the rollback of its partial changes to the owner
+type txHandle struct {
+	*sql.Tx
+	joined bool
+}
+
+func (t *txHandle) Commit() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *txHandle) Rollback() error {
+	if t.joined {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// begin starts a transaction on the connection pool or joins the transaction db is
+func begin(db executor) (*txHandle, error) {
+	switch conn := db.(type) {
+	case *sql.Tx:
+		return &txHandle{Tx: conn, joined: true}, nil
+	case *sql.DB:
+		tx, err := conn.Begin()
+		if err != nil {
+			return nil, err
+		}
+		return &txHandle{Tx: tx}, nil
+	default:
+		return nil, fmt.Errorf("cannot begin a transaction on %T", db)
+	}
+}
+
+type transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new PostgreSQL transactor
+func NewTransactor(db *sql.DB) *transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTx(fn func(repos repository.Repositories) error) error {
+	tx, err := t.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback() // Ignore error - transaction may already be committed
+	}()
+
+	repos := repository.Repositories{
+		Teams:        &teamRepository{db: tx},
+		Users:        &userRepository{db: tx},
+		PullRequests: &pullRequestRepository{db: tx},
+		Plans:        &deactivationPlanRepository{db: tx},
+		Jobs:         &jobRepository{db: tx},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
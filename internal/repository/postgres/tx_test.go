@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactor_WithinTx_RollsBackOnError(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	createTestPR(t, db)
+	errFailed := errors.New("failed")
+
+	err := NewTransactor(db).WithinTx(func(repos repository.Repositories) error {
+		require.NoError(t, repos.Users.BulkSetIsActive([]string{"u2"}, false))
+		// CreateTeam joins the transaction instead of committing on its own
+		require.NoError(t, repos.Teams.CreateTeam(&domain.Team{
+			TeamName: "frontend",
+			Members:  []domain.TeamMember{{UserID: "u5", Username: "Eve", IsActive: true}},
+		}))
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	user, err := NewUserRepository(db).GetUser("u2")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+
+	exists, err := NewTeamRepository(db).TeamExists("frontend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTransactor_WithinTx_Commits(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	createTestPR(t, db)
+
+	err := NewTransactor(db).WithinTx(func(repos repository.Repositories) error {
+		if err := repos.Users.BulkSetIsActive([]string{"u2"}, false); err != nil {
+			return err
+		}
+		return repos.Teams.CreateTeam(&domain.Team{
+			TeamName: "frontend",
+			Members:  []domain.TeamMember{{UserID: "u5", Username: "Eve", IsActive: true}},
+		})
+	})
+	require.NoError(t, err)
+
+	user, err := NewUserRepository(db).GetUser("u2")
+	require.NoError(t, err)
+	assert.False(t, user.IsActive)
+
+	exists, err := NewTeamRepository(db).TeamExists("frontend")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
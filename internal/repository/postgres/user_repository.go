@@ -7,10 +7,21 @@ import (
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// userTagsColumn selects tags of the user row aliased as u
+	userTagsColumn = "ARRAY(SELECT tag FROM user_tags WHERE user_id = u.user_id ORDER BY tag)"
+
+	// userAbsentColumn reports whether an absence of the user row aliased as u is in effect
+	userAbsentColumn = `EXISTS(SELECT 1 FROM user_absences a WHERE a.user_id = u.user_id
+		AND a.cancelled_at IS NULL AND a.starts_at <= NOW() AND a.ends_at > NOW())`
 )
 
 type userRepository struct {
-	db *sql.DB
+	db executor
 }
 
 // NewUserRepository creates a new PostgreSQL user repository
@@ -20,16 +31,23 @@ func NewUserRepository(db *sql.DB) *userRepository {
 
 func (r *userRepository) GetUser(userID string) (*domain.User, error) {
 	var user domain.User
+	var maxOpenReviews sql.NullInt64
 	err := r.db.QueryRow(
-		"SELECT user_id, username, team_name, is_active FROM users WHERE user_id = $1",
+		`SELECT u.user_id, u.username, u.team_name, u.is_active, u.max_open_reviews, `+userTagsColumn+`,
+		        `+userAbsentColumn+`
+		 FROM users u WHERE u.user_id = $1`,
 		userID,
-	).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	).Scan(
+		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &maxOpenReviews, pq.Array(&user.Tags),
+		&user.IsAbsent,
+	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, repository.ErrNotFound
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	user.MaxOpenReviews = nullIntPtr(maxOpenReviews)
 	return &user, nil
 }
 
@@ -46,7 +64,8 @@ func (r *userRepository) SetIsActive(userID string, isActive bool) (*domain.User
 }
 
 func (r *userRepository) GetActiveUsersByTeam(teamName string, excludeUserIDs []string) ([]*domain.User, error) {
-	query := "SELECT user_id, username, team_name, is_active FROM users WHERE team_name = $1 AND is_active = true"
+	query := `SELECT u.user_id, u.username, u.team_name, u.is_active, u.max_open_reviews, ` + userTagsColumn + `
+		FROM users u WHERE u.team_name = $1 AND u.is_active = true AND NOT ` + userAbsentColumn
 	args := []interface{}{teamName}
 
 	if len(excludeUserIDs) > 0 {
@@ -55,10 +74,10 @@ func (r *userRepository) GetActiveUsersByTeam(teamName string, excludeUserIDs []
 			args = append(args, id)
 			placeholders[i] = fmt.Sprintf("$%d", i+2) // +2 because $1 is teamName
 		}
-		query += fmt.Sprintf(" AND user_id NOT IN (%s)", strings.Join(placeholders, ", "))
+		query += fmt.Sprintf(" AND u.user_id NOT IN (%s)", strings.Join(placeholders, ", "))
 	}
 
-	query += " ORDER BY user_id"
+	query += " ORDER BY u.user_id"
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
@@ -69,9 +88,13 @@ func (r *userRepository) GetActiveUsersByTeam(teamName string, excludeUserIDs []
 	var users []*domain.User
 	for rows.Next() {
 		var user domain.User
-		if err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive); err != nil {
+		var maxOpenReviews sql.NullInt64
+		if err := rows.Scan(
+			&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &maxOpenReviews, pq.Array(&user.Tags),
+		); err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
+		user.MaxOpenReviews = nullIntPtr(maxOpenReviews)
 		users = append(users, &user)
 	}
 
@@ -84,11 +107,12 @@ func (r *userRepository) GetActiveUsersByTeam(teamName string, excludeUserIDs []
 
 func (r *userRepository) CreateOrUpdateUser(user *domain.User) error {
 	_, err := r.db.Exec(
-		`INSERT INTO users (user_id, username, team_name, is_active) 
-		 VALUES ($1, $2, $3, $4)
+		`INSERT INTO users (user_id, username, team_name, is_active, max_open_reviews) 
+		 VALUES ($1, $2, $3, $4, $5)
 		 ON CONFLICT (user_id) 
-		 DO UPDATE SET username = $2, team_name = $3, is_active = $4, updated_at = CURRENT_TIMESTAMP`,
-		user.UserID, user.Username, user.TeamName, user.IsActive,
+		 DO UPDATE SET username = $2, team_name = $3, is_active = $4, max_open_reviews = $5,
+		               updated_at = CURRENT_TIMESTAMP`,
+		user.UserID, user.Username, user.TeamName, user.IsActive, user.MaxOpenReviews,
 	)
 	return err
 }
@@ -115,3 +139,68 @@ func (r *userRepository) BulkSetIsActive(userIDs []string, isActive bool) error
 	_, err := r.db.Exec(query, args...)
 	return err
 }
+
+func (r *userRepository) SetMaxOpenReviews(userID string, maxOpenReviews *int) (*domain.User, error) {
+	_, err := r.db.Exec(
+		"UPDATE users SET max_open_reviews = $1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $2",
+		maxOpenReviews, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user capacity: %w", err)
+	}
+
+	return r.GetUser(userID)
+}
+
+func (r *userRepository) AddUserTags(userID string, tags []string) (*domain.User, error) {
+	_, err := r.db.Exec(
+		`INSERT INTO user_tags (user_id, tag)
+		 SELECT user_id, UNNEST($2::text[]) FROM users WHERE user_id = $1
+		 ON CONFLICT (user_id, tag) DO NOTHING`,
+		userID, pq.Array(tags),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add user tags: %w", err)
+	}
+
+	return r.GetUser(userID)
+}
+
+func (r *userRepository) RemoveUserTags(userID string, tags []string) (*domain.User, error) {
+	_, err := r.db.Exec(
+		"DELETE FROM user_tags WHERE user_id = $1 AND tag = ANY($2)",
+		userID, pq.Array(tags),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove user tags: %w", err)
+	}
+
+	return r.GetUser(userID)
+}
+
+// replaceUserTags sets the user's tags to exactly tags within the transaction
+func replaceUserTags(tx executor, userID string, tags []string) error {
+	if _, err := tx.Exec("DELETE FROM user_tags WHERE user_id = $1", userID); err != nil {
+		return fmt.Errorf("failed to clear user tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	_, err := tx.Exec(
+		"INSERT INTO user_tags (user_id, tag) SELECT $1, UNNEST($2::text[]) ON CONFLICT DO NOTHING",
+		userID, pq.Array(tags),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert user tags: %w", err)
+	}
+	return nil
+}
+
+// nullIntPtr converts a nullable integer column into an optional int
+func nullIntPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int64)
+	return &n
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_BulkSetIsActive(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestDB(t, db)
+
+	require.NoError(t, NewTeamRepository(db).CreateTeam(&domain.Team{
+		TeamName: "backend",
+		Members: []domain.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	}))
+
+	repo := NewUserRepository(db)
+	require.NoError(t, repo.BulkSetIsActive([]string{"u2", "u3"}, false))
+	// An empty list changes nothing
+	require.NoError(t, repo.BulkSetIsActive(nil, true))
+
+	for userID, isActive := range map[string]bool{"u1": true, "u2": false, "u3": false} {
+		user, err := repo.GetUser(userID)
+		require.NoError(t, err)
+		assert.Equal(t, isActive, user.IsActive, userID)
+	}
+
+	require.NoError(t, repo.BulkSetIsActive([]string{"u3"}, true))
+	user, err := repo.GetUser("u3")
+	require.NoError(t, err)
+	assert.True(t, user.IsActive)
+}
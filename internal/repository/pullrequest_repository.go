@@ -1,33 +1,122 @@
 package repository
 
-import "avito-tech-internship/internal/domain"
+import (
+	"time"
+
+	"avito-tech-internship/internal/domain"
+)
+
+// PRCursor is the keyset position of a PR in a listing ordered by creation time
+type PRCursor struct {
+	CreatedAt     time.Time
+	PullRequestID string
+}
 
 // PullRequestRepository defines the interface for pull request operations
 type PullRequestRepository interface {
-	// CreatePR creates a new pull request
-	CreatePR(pr *domain.PullRequest) error
+	// CreatePR creates a new pull request, recording an assign event for each of its reviewers
+	CreatePR(pr *domain.PullRequest, change domain.AssignmentChange) error
 
 	// GetPR retrieves a pull request by ID with assigned reviewers
 	GetPR(prID string) (*domain.PullRequest, error)
 
-	// UpdatePR updates an existing pull request
-	UpdatePR(pr *domain.PullRequest) error
+	// GetPRForUpdate retrieves a pull request like GetPR, locking it until the transaction ends
+	GetPRForUpdate(prID string) (*domain.PullRequest, error)
+
+	// UpdatePR updates an existing pull request; reviewers that were added or removed
+	// are recorded as assign and unassign events
+	UpdatePR(pr *domain.PullRequest, change domain.AssignmentChange) error
+
+	// UpdatePRMetadata updates name, description, labels, priority, size and dependencies of a PR
+	UpdatePRMetadata(pr *domain.PullRequest) error
+
+	// GetDependencyClosure returns IDs of all PRs the given PRs depend on, directly or through other PRs
+	GetDependencyClosure(prIDs []string) ([]string, error)
 
-	// MergePR marks a PR as merged (idempotent)
-	MergePR(prID string) (*domain.PullRequest, error)
+	// GetUnmergedDependencies returns IDs of PRs the PR directly depends on that are not merged yet
+	GetUnmergedDependencies(prID string) ([]string, error)
+
+	// GetDependents returns IDs of PRs that directly depend on the PR
+	GetDependents(prID string) ([]string, error)
+
+	// MergePR marks an OPEN PR as merged, keeping its reviewers; ErrNotFound when there is no OPEN PR
+	// with the ID. A forced merge is flagged on the PR and recorded in its history with the actor
+	MergePR(prID string, force bool, actor string) error
 
 	// PRExists checks if a PR with given ID exists
 	PRExists(prID string) (bool, error)
 
-	// GetPRsByReviewer returns all PRs where the user is assigned as reviewer
-	GetPRsByReviewer(userID string) ([]*domain.PullRequestShort, error)
+	// DeletePR removes a PR together with its reviewers, reviews, declines, tags and labels;
+	// its assignment history is kept
+	DeletePR(prID string) error
+
+	// ArchiveMergedPRs archives PRs merged before the given time and returns how many were archived
+	ArchiveMergedPRs(mergedBefore time.Time) (int, error)
+
+	// GetPRsByReviewer returns all PRs where the user is assigned as reviewer, skipping archived PRs
+	// unless includeArchived is set
+	GetPRsByReviewer(userID string, includeArchived bool) ([]*domain.PullRequestShort, error)
+
+	// GetPRsAwaitingVerdict returns OPEN PRs where the user is assigned and has not yet approved
+	// or requested changes (a COMMENTED verdict still awaits a decision)
+	GetPRsAwaitingVerdict(userID string) ([]*domain.PullRequestShort, error)
 
-	// ReassignReviewer replaces one reviewer with another
-	ReassignReviewer(prID string, oldUserID string, newUserID string) error
+	// ListPRs returns up to limit PRs matching the query, ordered by creation time and then by ID,
+	// that come after the cursor (nil = from the start)
+	ListPRs(query domain.PRQuery, after *PRCursor, limit int) ([]*domain.PullRequestShort, error)
 
-	// GetStats retrieves statistics about PR assignments
-	GetStats() (*domain.Stats, error)
+	// SubmitReview records a verdict of a reviewer on an OPEN PR; ErrNotFound when there is no OPEN PR with the ID
+	SubmitReview(prID string, userID string, verdict domain.ReviewVerdict) error
 
-	// GetOpenPRsByReviewers returns all OPEN PRs where any of the given users are reviewers
+	// ReassignReviewer replaces one reviewer with the replacement, recording how it was selected and
+	// an event of the change type; isFallback marks a reviewer from another team
+	ReassignReviewer(
+		prID string,
+		oldUserID string,
+		replacement domain.ReviewerSelection,
+		isFallback bool,
+		change domain.AssignmentChange,
+	) error
+
+	// AddReviewer assigns a manually chosen reviewer without a selection record and records an assign event;
+	// isFallback marks a reviewer from another team
+	AddReviewer(prID string, userID string, isFallback bool, change domain.AssignmentChange) error
+
+	// RemoveReviewer unassigns a reviewer from a PR and records an unassign event
+	RemoveReviewer(prID string, userID string, change domain.AssignmentChange) error
+
+	// DeclineReview records that the reviewer declined the PR and replaces them with the replacement,
+	// or just unassigns them when replacement is nil; isFallback marks a replacement from another team.
+	// The change is recorded as an event with the reviewer as the actor and the reason
+	DeclineReview(
+		prID string,
+		userID string,
+		reason string,
+		replacement *domain.ReviewerSelection,
+		isFallback bool,
+	) error
+
+	// GetAssignmentHistory returns the assignment events of a PR in the order they happened
+	GetAssignmentHistory(prID string) ([]*domain.AssignmentEvent, error)
+
+	// MarkOverdueAssignments marks assignments on OPEN PRs still awaiting a verdict after the review SLA
+	// of the author's team as overdue at now, and returns the newly marked ones
+	MarkOverdueAssignments(now time.Time) ([]*domain.OverdueAssignment, error)
+
+	// GetOverdueAssignments returns overdue assignments still awaiting a verdict, optionally limited
+	// to PRs of the given author team (empty = all teams)
+	GetOverdueAssignments(teamName string) ([]*domain.OverdueAssignment, error)
+
+	// GetStats retrieves statistics about PR assignments, skipping archived PRs unless includeArchived is set
+	GetStats(includeArchived bool) (*domain.Stats, error)
+
+	// GetOpenPRsByReviewers returns all OPEN PRs where any of the given users are reviewers, with the
+	// priority, size, required tags and dependencies reviewer selection relies on
 	GetOpenPRsByReviewers(userIDs []string) ([]*domain.PullRequest, error)
+
+	// GetOpenReviewCountsByTeam returns the number of OPEN PRs each team member reviews, keyed by user ID
+	GetOpenReviewCountsByTeam(teamName string) (map[string]int, error)
+
+	// GetRecentPairings returns how many PRs of the author created since the given time each user reviewed
+	GetRecentPairings(authorID string, since time.Time) (map[string]int, error)
 }
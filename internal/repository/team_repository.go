@@ -12,4 +12,16 @@ type TeamRepository interface {
 
 	// TeamExists checks if a team with given name exists
 	TeamExists(teamName string) (bool, error)
+
+	// GetTeamSettings retrieves reviewer assignment settings of a team
+	GetTeamSettings(teamName string) (*domain.TeamSettings, error)
+
+	// UpdateTeamSettings stores reviewer assignment settings of a team
+	UpdateTeamSettings(settings *domain.TeamSettings) error
+
+	// GetCodeOwners retrieves the CODEOWNERS-style ownership file of a team
+	GetCodeOwners(teamName string) (string, error)
+
+	// SetCodeOwners stores the CODEOWNERS-style ownership file of a team
+	SetCodeOwners(teamName string, content string) error
 }
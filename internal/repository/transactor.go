@@ -0,0 +1,17 @@
+package repository
+
+// Repositories are repositories bound to one transaction
+type Repositories struct {
+	Teams        TeamRepository
+	Users        UserRepository
+	PullRequests PullRequestRepository
+	Plans        DeactivationPlanRepository
+	Jobs         JobRepository
+}
+
+// Transactor runs work on several repositories atomically
+type Transactor interface {
+	// WithinTx runs fn with repositories bound to one transaction, which is committed when fn
+	// returns nil and rolled back otherwise
+	WithinTx(fn func(repos Repositories) error) error
+}
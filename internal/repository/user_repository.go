@@ -10,7 +10,7 @@ type UserRepository interface {
 	// SetIsActive updates the is_active flag for a user
 	SetIsActive(userID string, isActive bool) (*domain.User, error)
 
-	// GetActiveUsersByTeam returns all active users in a team (excluding specified user IDs)
+	// GetActiveUsersByTeam returns all active users in a team who are not absent (excluding specified user IDs)
 	GetActiveUsersByTeam(teamName string, excludeUserIDs []string) ([]*domain.User, error)
 
 	// CreateOrUpdateUser creates a new user or updates existing one
@@ -18,4 +18,13 @@ type UserRepository interface {
 
 	// BulkSetIsActive updates is_active flag for multiple users
 	BulkSetIsActive(userIDs []string, isActive bool) error
+
+	// SetMaxOpenReviews updates the review capacity of a user (nil removes the personal limit)
+	SetMaxOpenReviews(userID string, maxOpenReviews *int) (*domain.User, error)
+
+	// AddUserTags adds tags to a user, ignoring tags the user already has
+	AddUserTags(userID string, tags []string) (*domain.User, error)
+
+	// RemoveUserTags removes tags from a user
+	RemoveUserTags(userID string, tags []string) (*domain.User, error)
 }
@@ -0,0 +1,86 @@
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/handler"
+	"avito-tech-internship/internal/service"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// maxAuditBody is how much of a request body is read for its audit summary; enough to compact
+// an indented body down to service.MaxAuditSummaryLength
+const maxAuditBody = 64 << 10
+
+// auditLog records every POST request in the audit log once it has been handled,
+// including requests rejected before reaching their handler and requests ending in a panic
+func auditLog(auditService *service.AuditService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Only the start of the body is read for the summary; the handler gets the whole body unchanged
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxAuditBody+1))
+			if err != nil {
+				slog.Error("Failed to read request body for audit", "error", err, "endpoint", r.URL.Path)
+			}
+			r.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				status := ww.Status()
+				// A panic is answered with 500 by Recoverer once it is passed on
+				rvr := recover()
+				if rvr != nil {
+					status = http.StatusInternalServerError
+				} else if status == 0 {
+					status = http.StatusOK
+				}
+
+				record := &domain.AuditRecord{
+					Actor:          handler.ActorFrom(r),
+					Method:         r.Method,
+					Endpoint:       r.URL.Path,
+					RequestSummary: summarizeBody(body),
+					ResultCode:     status,
+					RequestID:      middleware.GetReqID(r.Context()),
+				}
+				if err := auditService.Record(record); err != nil {
+					slog.Error("Failed to record audit log", "error", err, "endpoint", r.URL.Path)
+				}
+
+				if rvr != nil {
+					panic(rvr)
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+// summarizeBody returns a JSON body without insignificant whitespace, or the raw body when it is not JSON.
+// A body longer than maxAuditBody is summarized by its start
+func summarizeBody(body []byte) string {
+	if len(body) > maxAuditBody {
+		return string(body[:maxAuditBody])
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err != nil {
+		return string(body)
+	}
+	return compacted.String()
+}
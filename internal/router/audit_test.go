@@ -0,0 +1,66 @@
+package router
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/service"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAuditRepository keeps created records in memory
+type recordingAuditRepository struct {
+	records []*domain.AuditRecord
+}
+
+func (r *recordingAuditRepository) CreateRecord(record *domain.AuditRecord) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+func (r *recordingAuditRepository) ListRecords(domain.AuditQuery, int64, int) ([]*domain.AuditRecord, error) {
+	return r.records, nil
+}
+
+func TestAuditLog_PassesLargeBodyThrough(t *testing.T) {
+	auditRepo := &recordingAuditRepository{}
+	body := `{"teams":"` + strings.Repeat("a", 2*maxAuditBody) + `"}`
+
+	var received string
+	h := auditLog(service.NewAuditService(auditRepo))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received = string(data)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/jobs/importTeams", strings.NewReader(body)))
+
+	assert.Equal(t, body, received)
+	require.Len(t, auditRepo.records, 1)
+	assert.Equal(t, http.StatusAccepted, auditRepo.records[0].ResultCode)
+	assert.Len(t, auditRepo.records[0].RequestSummary, service.MaxAuditSummaryLength)
+}
+
+func TestAuditLog_RecordsPanicsBehindRecoverer(t *testing.T) {
+	auditRepo := &recordingAuditRepository{}
+	h := middleware.Recoverer(auditLog(service.NewAuditService(auditRepo))(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}),
+	))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(`{}`)))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.Len(t, auditRepo.records, 1)
+	assert.Equal(t, http.StatusInternalServerError, auditRepo.records[0].ResultCode)
+}
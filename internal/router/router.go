@@ -1,20 +1,18 @@
 package router
 
 import (
-	"database/sql"
 	"net/http"
 	"time"
 
+	"avito-tech-internship/internal/config"
 	"avito-tech-internship/internal/handler"
-	"avito-tech-internship/internal/repository/postgres"
-	"avito-tech-internship/internal/service"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
 
 // SetupRouter creates and configures the HTTP router with all routes
-func SetupRouter(db *sql.DB) *chi.Mux {
+func SetupRouter(services *Services, admin config.AdminConfig) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware
@@ -22,6 +20,8 @@ func SetupRouter(db *sql.DB) *chi.Mux {
 	r.Use(middleware.RealIP)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	// Requests ending in a panic are audited with the 500 Recoverer answers them with
+	r.Use(auditLog(services.Audit))
 	r.Use(middleware.Timeout(60 * time.Second))
 
 	// Health check endpoint
@@ -38,44 +38,72 @@ func SetupRouter(db *sql.DB) *chi.Mux {
 	r.Get("/swagger/", handler.ServeSwaggerUI)
 	r.HandleFunc("/swagger/*", handler.ServeSwaggerUI)
 
-	// Initialize repositories
-	teamRepo := postgres.NewTeamRepository(db)
-	userRepo := postgres.NewUserRepository(db)
-	prRepo := postgres.NewPullRequestRepository(db)
-
-	// Initialize services
-	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, teamRepo)
-	bulkDeactivateService := service.NewBulkDeactivateService(userRepo, prRepo, teamRepo)
-
 	// Initialize handlers
-	teamHandler := handler.NewTeamHandler(teamService)
-	userHandler := handler.NewUserHandler(userService, prService)
-	prHandler := handler.NewPullRequestHandler(prService)
-	statsHandler := handler.NewStatsHandler(prService)
-	bulkDeactivateHandler := handler.NewBulkDeactivateHandler(bulkDeactivateService)
+	teamHandler := handler.NewTeamHandler(services.Team)
+	userHandler := handler.NewUserHandler(services.User, services.PullRequest)
+	prHandler := handler.NewPullRequestHandler(services.PullRequest, admin.Token)
+	statsHandler := handler.NewStatsHandler(services.PullRequest)
+	bulkDeactivateHandler := handler.NewBulkDeactivateHandler(services.BulkDeactivate, services.Job)
+	absenceHandler := handler.NewAbsenceHandler(services.Absence)
+	slaHandler := handler.NewSLAHandler(services.SLA)
+	auditHandler := handler.NewAuditHandler(services.Audit)
+	jobHandler := handler.NewJobHandler(services.Job)
 
 	// API routes
 	r.Route("/team", func(r chi.Router) {
 		r.Post("/add", teamHandler.CreateTeam)
 		r.Get("/get", teamHandler.GetTeam)
+		r.Get("/getSettings", teamHandler.GetSettings)
+		r.Post("/setSettings", teamHandler.SetSettings)
+		r.Get("/getCodeOwners", teamHandler.GetCodeOwners)
+		r.Post("/setCodeOwners", teamHandler.SetCodeOwners)
 	})
 
 	r.Route("/users", func(r chi.Router) {
 		r.Post("/setIsActive", userHandler.SetIsActive)
+		r.Post("/setMaxOpenReviews", userHandler.SetMaxOpenReviews)
+		r.Get("/tags", userHandler.GetTags)
+		r.Post("/tags/add", userHandler.AddTags)
+		r.Post("/tags/remove", userHandler.RemoveTags)
 		r.Get("/getReview", userHandler.GetReview)
 		r.Post("/bulkDeactivate", bulkDeactivateHandler.BulkDeactivate)
+		r.Get("/absences", absenceHandler.ListAbsences)
+		r.Post("/absences/create", absenceHandler.CreateAbsence)
+		r.Post("/absences/cancel", absenceHandler.CancelAbsence)
 	})
 
 	r.Route("/pullRequest", func(r chi.Router) {
 		r.Post("/create", prHandler.CreatePR)
+		r.Post("/update", prHandler.UpdatePR)
+		r.Get("/list", prHandler.ListPRs)
 		r.Post("/merge", prHandler.MergePR)
+		r.Post("/ready", prHandler.MarkReady)
+		r.Post("/close", prHandler.ClosePR)
+		r.Post("/reopen", prHandler.ReopenPR)
 		r.Post("/reassign", prHandler.ReassignReviewer)
+		r.Post("/addReviewer", prHandler.AddReviewer)
+		r.Post("/removeReviewer", prHandler.RemoveReviewer)
+		r.Post("/decline", prHandler.DeclineReview)
+		r.Get("/history", prHandler.GetHistory)
+		r.Get("/overdue", slaHandler.GetOverdue)
+		r.Post("/review", prHandler.SubmitReview)
+		r.With(handler.RequireAdmin(admin.Token)).Post("/delete", prHandler.DeletePR)
+	})
+
+	r.Route("/jobs", func(r chi.Router) {
+		r.Post("/bulkDeactivate", jobHandler.BulkDeactivate)
+		r.Post("/importTeams", jobHandler.ImportTeams)
+		r.Post("/massReassign", jobHandler.MassReassign)
+		r.Get("/{id}", jobHandler.GetJob)
 	})
 
 	// Statistics endpoint
 	r.Get("/stats", statsHandler.GetStats)
 
+	r.Route("/admin", func(r chi.Router) {
+		r.Use(handler.RequireAdmin(admin.Token))
+		r.Get("/audit", auditHandler.ListRecords)
+	})
+
 	return r
 }
@@ -0,0 +1,58 @@
+package router
+
+import (
+	"database/sql"
+
+	"avito-tech-internship/internal/config"
+	"avito-tech-internship/internal/repository/postgres"
+	"avito-tech-internship/internal/service"
+)
+
+// Services holds the service instances shared by the HTTP handlers and the background jobs.
+// They are built once so that reviewer selection keeps a single seed sequence and round-robin cursor
+type Services struct {
+	Team           *service.TeamService
+	User           *service.UserService
+	PullRequest    *service.PullRequestService
+	BulkDeactivate *service.BulkDeactivateService
+	Absence        *service.AbsenceService
+	SLA            *service.SLAService
+	Audit          *service.AuditService
+	Job            *service.JobService
+}
+
+// NewServices creates the repositories and services backed by db
+func NewServices(db *sql.DB, selection config.SelectionConfig) *Services {
+	// Initialize repositories
+	teamRepo := postgres.NewTeamRepository(db)
+	userRepo := postgres.NewUserRepository(db)
+	prRepo := postgres.NewPullRequestRepository(db)
+	absenceRepo := postgres.NewAbsenceRepository(db)
+
+	// Initialize services
+	transactor := postgres.NewTransactor(db)
+	teamService := service.NewTeamService(teamRepo)
+	prService := service.NewPullRequestService(transactor, prRepo, userRepo, teamRepo)
+	if selection.Seed != nil {
+		prService.SetSeed(*selection.Seed)
+	}
+	bulkDeactivateService := service.NewBulkDeactivateService(
+		transactor,
+		postgres.NewDeactivationPlanRepository(db),
+		prService,
+	)
+
+	return &Services{
+		Team:           teamService,
+		User:           service.NewUserService(userRepo),
+		PullRequest:    prService,
+		BulkDeactivate: bulkDeactivateService,
+		Absence:        service.NewAbsenceService(absenceRepo, userRepo, prRepo, prService),
+		SLA:            service.NewSLAService(prRepo, teamRepo, prService),
+		Audit:          service.NewAuditService(postgres.NewAuditRepository(db)),
+		// Jobs are only queued by the handlers, the workers of cmd/server process them
+		Job: service.NewJobService(
+			transactor, postgres.NewJobRepository(db), teamService, bulkDeactivateService, prService,
+		),
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+var (
+	ErrAbsenceNotFound = errors.New("absence not found")
+	ErrInvalidAbsence  = errors.New("absence must end after it starts")
+)
+
+// AbsenceService manages out-of-office periods of users
+type AbsenceService struct {
+	absenceRepo repository.AbsenceRepository
+	userRepo    repository.UserRepository
+	prRepo      repository.PullRequestRepository
+	prService   *PullRequestService
+}
+
+func NewAbsenceService(
+	absenceRepo repository.AbsenceRepository,
+	userRepo repository.UserRepository,
+	prRepo repository.PullRequestRepository,
+	prService *PullRequestService,
+) *AbsenceService {
+	return &AbsenceService{
+		absenceRepo: absenceRepo,
+		userRepo:    userRepo,
+		prRepo:      prRepo,
+		prService:   prService,
+	}
+}
+
+// CreateAbsence schedules an absence of a user. When the absence has already started and asks for
+// reassignment, the user's open reviews are reassigned right away
+func (s *AbsenceService) CreateAbsence(absence *domain.Absence) error {
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return ErrInvalidAbsence
+	}
+
+	if _, err := s.userRepo.GetUser(absence.UserID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.absenceRepo.CreateAbsence(absence); err != nil {
+		return fmt.Errorf("failed to create absence: %w", err)
+	}
+
+	now := time.Now()
+	if absence.ReassignReviews && !absence.StartsAt.After(now) && absence.EndsAt.After(now) {
+		if err := s.reassignReviews(absence); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListAbsences returns absences of a user; past and cancelled ones are included only on request
+func (s *AbsenceService) ListAbsences(userID string, includePast bool) ([]*domain.Absence, error) {
+	if _, err := s.userRepo.GetUser(userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	absences, err := s.absenceRepo.ListAbsences(userID, includePast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list absences: %w", err)
+	}
+	return absences, nil
+}
+
+// CancelAbsence cancels an absence (idempotent operation)
+func (s *AbsenceService) CancelAbsence(absenceID int64) (*domain.Absence, error) {
+	absence, err := s.absenceRepo.CancelAbsence(absenceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrAbsenceNotFound
+		}
+		return nil, fmt.Errorf("failed to cancel absence: %w", err)
+	}
+	return absence, nil
+}
+
+// ReassignStartedAbsences reassigns open reviews of users whose absences with reassign_reviews
+// have started, and returns how many absences were processed. Failed absences are retried on the next call
+func (s *AbsenceService) ReassignStartedAbsences() (int, error) {
+	absences, err := s.absenceRepo.GetAbsencesToReassign(time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get absences to reassign: %w", err)
+	}
+
+	processed := 0
+	var errs []error
+	for _, absence := range absences {
+		if err := s.reassignReviews(absence); err != nil {
+			errs = append(errs, fmt.Errorf("absence %d: %w", absence.AbsenceID, err))
+			continue
+		}
+		processed++
+	}
+
+	return processed, errors.Join(errs...)
+}
+
+// reassignReviews moves open reviews of the absent user to other reviewers and marks the absence
+// as reassigned. Reviews nobody can take over are left with the absent user
+func (s *AbsenceService) reassignReviews(absence *domain.Absence) error {
+	user, err := s.userRepo.GetUser(absence.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	openPRs, err := s.prRepo.GetOpenPRsByReviewers([]string{absence.UserID})
+	if err != nil {
+		return fmt.Errorf("failed to get open PRs: %w", err)
+	}
+
+	for _, pr := range openPRs {
+		change := domain.AssignmentChange{Actor: SystemActor, Reason: "reviewer is absent"}
+		_, err := s.prService.replaceReviewer(pr, user, nil, change)
+		if err != nil && !errors.Is(err, ErrNoCandidate) && !errors.Is(err, ErrCapacityExhausted) {
+			return fmt.Errorf("failed to reassign PR %s: %w", pr.PullRequestID, err)
+		}
+	}
+
+	if err := s.absenceRepo.MarkReassigned(absence.AbsenceID); err != nil {
+		return fmt.Errorf("failed to mark absence reassigned: %w", err)
+	}
+	now := time.Now()
+	absence.ReassignedAt = &now
+	return nil
+}
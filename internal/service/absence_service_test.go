@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAbsenceRepository is a mock implementation of AbsenceRepository
+type MockAbsenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockAbsenceRepository) CreateAbsence(absence *domain.Absence) error {
+	args := m.Called(absence)
+	return args.Error(0)
+}
+
+func (m *MockAbsenceRepository) ListAbsences(userID string, includePast bool) ([]*domain.Absence, error) {
+	args := m.Called(userID, includePast)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Absence), args.Error(1)
+}
+
+func (m *MockAbsenceRepository) CancelAbsence(absenceID int64) (*domain.Absence, error) {
+	args := m.Called(absenceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Absence), args.Error(1)
+}
+
+func (m *MockAbsenceRepository) GetAbsencesToReassign(now time.Time) ([]*domain.Absence, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Absence), args.Error(1)
+}
+
+func (m *MockAbsenceRepository) MarkReassigned(absenceID int64) error {
+	args := m.Called(absenceID)
+	return args.Error(0)
+}
+
+func TestAbsenceService_CreateAbsence_RejectsEmptyPeriod(t *testing.T) {
+	service := NewAbsenceService(new(MockAbsenceRepository), new(MockUserRepository), new(MockPullRequestRepository), nil)
+
+	start := time.Now()
+	err := service.CreateAbsence(&domain.Absence{UserID: "u2", StartsAt: start, EndsAt: start})
+	assert.ErrorIs(t, err, ErrInvalidAbsence)
+}
+
+func TestAbsenceService_ReassignStartedAbsences(t *testing.T) {
+	mockAbsenceRepo := new(MockAbsenceRepository)
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	prService := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+	service := NewAbsenceService(mockAbsenceRepo, mockUserRepo, mockPRRepo, prService)
+
+	absence := &domain.Absence{AbsenceID: 7, UserID: "u2", ReassignReviews: true}
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+
+	mockAbsenceRepo.On("GetAbsencesToReassign", mock.AnythingOfType("time.Time")).Return([]*domain.Absence{absence}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsAbsent: true}, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]*domain.PullRequest{pr}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u3"}).Return([]*domain.User{
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4", false, domain.AssignmentChange{
+		Actor:  SystemActor,
+		Reason: "reviewer is absent",
+	}).Return(nil)
+	mockAbsenceRepo.On("MarkReassigned", int64(7)).Return(nil)
+
+	processed, err := service.ReassignStartedAbsences()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.Equal(t, []string{"u4", "u3"}, pr.AssignedReviewers)
+	assert.NotNil(t, absence.ReassignedAt)
+
+	mockAbsenceRepo.AssertExpectations(t)
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
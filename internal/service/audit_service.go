@@ -0,0 +1,103 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+const (
+	// DefaultAuditListLimit is the page size of the audit log when none is requested
+	DefaultAuditListLimit = 50
+	// MaxAuditListLimit caps the page size of the audit log
+	MaxAuditListLimit = 200
+	// MaxAuditSummaryLength caps the request summary stored in an audit record, in bytes
+	MaxAuditSummaryLength = 2048
+)
+
+// AuditService keeps the audit log of mutating API calls
+type AuditService struct {
+	auditRepo repository.AuditRepository
+}
+
+func NewAuditService(auditRepo repository.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// Record appends a record to the audit log, truncating its request summary to MaxAuditSummaryLength
+func (s *AuditService) Record(record *domain.AuditRecord) error {
+	record.RequestSummary = truncateUTF8(record.RequestSummary, MaxAuditSummaryLength)
+
+	if err := s.auditRepo.CreateRecord(record); err != nil {
+		return fmt.Errorf("failed to create audit record: %w", err)
+	}
+	return nil
+}
+
+// ListRecords returns a page of audit records matching the query, newest first. The cursor is the
+// NextCursor of the previous page (empty = first page); limit 0 uses DefaultAuditListLimit
+func (s *AuditService) ListRecords(query domain.AuditQuery, cursor string, limit int) (*domain.AuditPage, error) {
+	if limit == 0 {
+		limit = DefaultAuditListLimit
+	}
+	if limit < 0 || limit > MaxAuditListLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	var beforeID int64
+	if cursor != "" {
+		decoded, err := decodeAuditCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		beforeID = decoded
+	}
+
+	// One extra record tells whether there is a next page
+	records, err := s.auditRepo.ListRecords(query, beforeID, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit records: %w", err)
+	}
+
+	page := &domain.AuditPage{Records: records}
+	if len(records) > limit {
+		page.Records = records[:limit]
+		page.NextCursor = encodeAuditCursor(page.Records[limit-1].RecordID)
+	}
+
+	return page, nil
+}
+
+// encodeAuditCursor packs the ID of the last record of a page into an opaque URL-safe string
+func encodeAuditCursor(recordID int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(recordID, 10)))
+}
+
+// decodeAuditCursor unpacks a cursor made by encodeAuditCursor
+func decodeAuditCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	recordID, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || recordID <= 0 {
+		return 0, ErrInvalidCursor
+	}
+	return recordID, nil
+}
+
+// truncateUTF8 cuts s to at most maxBytes without splitting a multibyte character
+func truncateUTF8(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
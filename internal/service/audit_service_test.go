@@ -0,0 +1,94 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAuditRepository is a mock implementation of AuditRepository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) CreateRecord(record *domain.AuditRecord) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ListRecords(
+	query domain.AuditQuery,
+	beforeID int64,
+	limit int,
+) ([]*domain.AuditRecord, error) {
+	args := m.Called(query, beforeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AuditRecord), args.Error(1)
+}
+
+func TestAuditService_Record_TruncatesSummary(t *testing.T) {
+	mockAuditRepo := new(MockAuditRepository)
+	service := NewAuditService(mockAuditRepo)
+
+	mockAuditRepo.On("CreateRecord", mock.AnythingOfType("*domain.AuditRecord")).Return(nil)
+
+	// A two-byte character straddling the limit is dropped whole
+	record := &domain.AuditRecord{
+		Actor:          "alice",
+		Method:         "POST",
+		Endpoint:       "/team/add",
+		RequestSummary: strings.Repeat("a", MaxAuditSummaryLength-1) + "я",
+		ResultCode:     201,
+	}
+	assert.NoError(t, service.Record(record))
+	assert.Equal(t, strings.Repeat("a", MaxAuditSummaryLength-1), record.RequestSummary)
+
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_ListRecords_Pagination(t *testing.T) {
+	mockAuditRepo := new(MockAuditRepository)
+	service := NewAuditService(mockAuditRepo)
+
+	records := []*domain.AuditRecord{{RecordID: 30}, {RecordID: 20}, {RecordID: 10}}
+	query := domain.AuditQuery{Actor: "alice", ResultCode: 200}
+	mockAuditRepo.On("ListRecords", query, int64(0), 3).Return(records, nil)
+
+	page, err := service.ListRecords(query, "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, page.Records, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	beforeID, err := decodeAuditCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), beforeID)
+
+	mockAuditRepo.On("ListRecords", query, int64(20), 3).Return(records[2:], nil)
+
+	page, err = service.ListRecords(query, page.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Len(t, page.Records, 1)
+	assert.Empty(t, page.NextCursor)
+
+	mockAuditRepo.AssertExpectations(t)
+}
+
+func TestAuditService_ListRecords_InvalidQuery(t *testing.T) {
+	service := NewAuditService(new(MockAuditRepository))
+
+	_, err := service.ListRecords(domain.AuditQuery{}, "", MaxAuditListLimit+1)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = service.ListRecords(domain.AuditQuery{}, "%%%", 0)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = service.ListRecords(domain.AuditQuery{}, base64.RawURLEncoding.EncodeToString([]byte("-5")), 0)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -1,92 +1,484 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
+	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
 )
 
+var (
+	// ErrUserOutsideTeam is returned when a user to deactivate is not a member of the given team
+	ErrUserOutsideTeam = errors.New("user does not belong to the team")
+	ErrPlanNotFound    = errors.New("deactivation plan not found")
+	ErrPlanExecuted    = errors.New("deactivation plan is already executed")
+	// ErrPlanStale is returned when PRs or reviewers changed since the plan was computed,
+	// so executing it would no longer match the preview
+	ErrPlanStale = errors.New("deactivation plan is stale")
+)
+
 // BulkDeactivateService handles bulk deactivation of users with safe PR reassignment
 type BulkDeactivateService struct {
-	userRepo repository.UserRepository
-	prRepo   repository.PullRequestRepository
-	teamRepo repository.TeamRepository
+	transactor repository.Transactor
+	planRepo   repository.DeactivationPlanRepository
+	prService  *PullRequestService
 }
 
 func NewBulkDeactivateService(
-	userRepo repository.UserRepository,
-	prRepo repository.PullRequestRepository,
-	teamRepo repository.TeamRepository,
+	transactor repository.Transactor,
+	planRepo repository.DeactivationPlanRepository,
+	prService *PullRequestService,
 ) *BulkDeactivateService {
 	return &BulkDeactivateService{
-		userRepo: userRepo,
-		prRepo:   prRepo,
-		teamRepo: teamRepo,
+		transactor: transactor,
+		planRepo:   planRepo,
+		prService:  prService,
 	}
 }
 
-// BulkDeactivate deactivates multiple users in a team and safely reassigns reviewers in open PRs
-func (s *BulkDeactivateService) BulkDeactivate(teamName string, userIDs []string) error {
-	if len(userIDs) == 0 {
-		return fmt.Errorf("no users provided")
+// BulkDeactivate deactivates multiple users in a team and reassigns their reviews on open PRs in one
+// transaction: either every change is applied or none is. A deactivated reviewer nobody can replace is
+// removed from the PR and reported as an unfilled slot; the actor is recorded in the assignment history
+func (s *BulkDeactivateService) BulkDeactivate(
+	teamName string,
+	userIDs []string,
+	actor string,
+) (*domain.BulkDeactivateResult, error) {
+	var steps []domain.DeactivationStep
+	err := s.transactor.WithinTx(func(repos repository.Repositories) error {
+		var err error
+		steps, err = s.deactivate(repos, teamName, userIDs, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return newBulkDeactivateResult(teamName, userIDs, steps), nil
+}
+
+// PlanBulkDeactivate computes what BulkDeactivate would do without changing anything and stores it
+// as a plan; ExecutePlan applies it as previewed. Replacements are picked as BulkDeactivate picks them,
+// with earlier picks counted as open reviews of the picked users instead of being written, and with
+// selector state of its own, so a dry run neither takes locks nor moves the round-robin cursor
+func (s *BulkDeactivateService) PlanBulkDeactivate(
+	teamName string,
+	userIDs []string,
+) (*domain.BulkDeactivateResult, error) {
+	var steps []domain.DeactivationStep
+	// The transaction only reads, it keeps the plan consistent with one state of the database
+	err := s.transactor.WithinTx(func(repos repository.Repositories) error {
+		users, err := validateUsers(repos, teamName, userIDs)
+		if err != nil {
+			return err
+		}
+
+		openPRs, err := repos.PullRequests.GetOpenPRsByReviewers(userIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get open PRs: %w", err)
+		}
 
-	_, err := s.teamRepo.GetTeam(teamName)
+		planned := newPlannedReviews(repos.PullRequests)
+		planRepos := repos
+		planRepos.PullRequests = planned
+		steps, err = pickSteps(s.prService.forPlanning(planRepos), users, userIDs, openPRs,
+			func(pr *domain.PullRequest, step domain.DeactivationStep) error {
+				return planned.record(repos.Users, pr, users[step.OldUserID], step)
+			})
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("team not found: %w", err)
+		return nil, err
 	}
 
-	for _, userID := range userIDs {
-		user, getUserErr := s.userRepo.GetUser(userID)
-		if getUserErr != nil {
-			return fmt.Errorf("user %s not found: %w", userID, getUserErr)
+	plan := &domain.BulkDeactivatePlan{TeamName: teamName, UserIDs: userIDs, Steps: steps}
+	if err := s.planRepo.CreatePlan(plan); err != nil {
+		return nil, fmt.Errorf("failed to store deactivation plan: %w", err)
+	}
+
+	result := newBulkDeactivateResult(teamName, userIDs, steps)
+	result.PlanID = plan.PlanID
+	return result, nil
+}
+
+// ExecutePlan applies a plan computed by PlanBulkDeactivate exactly as it was previewed, in one
+// transaction. A plan runs once, and it is rejected as stale when the reviews of the users to
+// deactivate or the picked replacements changed since it was computed
+func (s *BulkDeactivateService) ExecutePlan(planID int64, actor string) (*domain.BulkDeactivateResult, error) {
+	var plan *domain.BulkDeactivatePlan
+	err := s.transactor.WithinTx(func(repos repository.Repositories) error {
+		var err error
+		plan, err = s.executePlan(repos, planID, actor)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := newBulkDeactivateResult(plan.TeamName, plan.UserIDs, plan.Steps)
+	result.PlanID = plan.PlanID
+	return result, nil
+}
+
+// executePlan applies the plan on repos and marks it executed, returning the applied plan
+func (s *BulkDeactivateService) executePlan(
+	repos repository.Repositories,
+	planID int64,
+	actor string,
+) (*domain.BulkDeactivatePlan, error) {
+	plan, err := repos.Plans.GetPlan(planID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPlanNotFound
 		}
-		if user.TeamName != teamName {
-			return fmt.Errorf("user %s does not belong to team %s", userID, teamName)
+		return nil, fmt.Errorf("failed to get deactivation plan: %w", err)
+	}
+	if plan.ExecutedAt != nil {
+		return nil, ErrPlanExecuted
+	}
+
+	if _, err := validateUsers(repos, plan.TeamName, plan.UserIDs); err != nil {
+		return nil, err
+	}
+	if err := checkPlan(repos, plan); err != nil {
+		return nil, err
+	}
+
+	if err := repos.Users.BulkSetIsActive(plan.UserIDs, false); err != nil {
+		return nil, fmt.Errorf("failed to deactivate users: %w", err)
+	}
+	for _, step := range plan.Steps {
+		if err := applyStep(repos, step, actor); err != nil {
+			return nil, err
 		}
 	}
 
-	openPRs, err := s.prRepo.GetOpenPRsByReviewers(userIDs)
+	if err := repos.Plans.MarkPlanExecuted(planID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to mark deactivation plan executed: %w", err)
+	}
+	return plan, nil
+}
+
+// deactivate runs a bulk deactivation on repositories bound to its transaction and returns its steps.
+// Every step is applied before the next replacement is picked, so later picks see earlier ones
+func (s *BulkDeactivateService) deactivate(
+	repos repository.Repositories,
+	teamName string,
+	userIDs []string,
+	actor string,
+) ([]domain.DeactivationStep, error) {
+	users, err := validateUsers(repos, teamName, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	openPRs, err := repos.PullRequests.GetOpenPRsByReviewers(userIDs)
 	if err != nil {
-		return fmt.Errorf("failed to get open PRs: %w", err)
+		return nil, fmt.Errorf("failed to get open PRs: %w", err)
 	}
 
-	if err := s.userRepo.BulkSetIsActive(userIDs, false); err != nil {
-		return fmt.Errorf("failed to deactivate users: %w", err)
+	if err := repos.Users.BulkSetIsActive(userIDs, false); err != nil {
+		return nil, fmt.Errorf("failed to deactivate users: %w", err)
 	}
 
-	for _, pr := range openPRs {
-		deactivatedReviewers := make([]string, 0)
+	return pickSteps(s.prService.withRepositories(repos), users, userIDs, openPRs,
+		func(_ *domain.PullRequest, step domain.DeactivationStep) error {
+			return applyStep(repos, step, actor)
+		})
+}
+
+// pickSteps picks a replacement for every review of the users on the open PRs, in order of PR and
+// reviewer ID, and passes each step to apply before picking the next one
+func pickSteps(
+	prService *PullRequestService,
+	users map[string]*domain.User,
+	userIDs []string,
+	openPRs []*domain.PullRequest,
+	apply func(pr *domain.PullRequest, step domain.DeactivationStep) error,
+) ([]domain.DeactivationStep, error) {
+	sorted := make([]*domain.PullRequest, len(openPRs))
+	copy(sorted, openPRs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].PullRequestID < sorted[j].PullRequestID
+	})
+
+	steps := []domain.DeactivationStep{}
+	for _, pr := range sorted {
+		// The PR's reviewers change while they are replaced, so the deactivated ones are collected first
+		var deactivatedReviewers []string
 		for _, reviewerID := range pr.AssignedReviewers {
-			for _, deactivatedID := range userIDs {
-				if reviewerID == deactivatedID {
-					deactivatedReviewers = append(deactivatedReviewers, reviewerID)
-					break
-				}
+			if containsString(userIDs, reviewerID) {
+				deactivatedReviewers = append(deactivatedReviewers, reviewerID)
 			}
 		}
+		sort.Strings(deactivatedReviewers)
 
 		for _, oldReviewerID := range deactivatedReviewers {
-			oldReviewer, err := s.userRepo.GetUser(oldReviewerID)
-			if err != nil {
-				continue // Skip if user not found
+			step := domain.DeactivationStep{PullRequestID: pr.PullRequestID, OldUserID: oldReviewerID}
+
+			selection, fromFallback, err := prService.planReplacement(pr, users[oldReviewerID], userIDs)
+			switch {
+			case err == nil:
+				step.Replacement = &selection
+				step.IsFallback = fromFallback
+			case errors.Is(err, ErrNoCandidate), errors.Is(err, ErrCapacityExhausted):
+				// Nobody can take the review over, the deactivated reviewer is removed anyway
+				step.Reason = err.Error()
+			default:
+				return nil, fmt.Errorf("failed to pick replacement of %s on PR %s: %w", oldReviewerID, pr.PullRequestID, err)
 			}
 
-			excludeIDs := append(userIDs, pr.AssignedReviewers...)
-			candidates, err := s.userRepo.GetActiveUsersByTeam(oldReviewer.TeamName, excludeIDs)
-			if err != nil || len(candidates) == 0 {
-				continue
+			if err := apply(pr, step); err != nil {
+				return nil, err
+			}
+			if step.Replacement != nil {
+				replaceAssigned(pr, oldReviewerID, step.Replacement.UserID)
 			}
+			steps = append(steps, step)
+		}
+	}
 
-			newReviewerID := candidates[0].UserID
+	return steps, nil
+}
 
-			if err := s.prRepo.ReassignReviewer(pr.PullRequestID, oldReviewerID, newReviewerID); err != nil {
-				// Log error but continue with other PRs
-				// In production, you might want to rollback or handle this differently
-				continue
+// validateUsers checks that the team exists and every user is its member, and returns the users by ID
+func validateUsers(
+	repos repository.Repositories,
+	teamName string,
+	userIDs []string,
+) (map[string]*domain.User, error) {
+	exists, err := repos.Teams.TeamExists(teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return nil, ErrTeamNotFound
+	}
+
+	users := make(map[string]*domain.User, len(userIDs))
+	for _, userID := range userIDs {
+		user, err := repos.Users.GetUser(userID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrUserNotFound
 			}
+			return nil, fmt.Errorf("failed to get user %s: %w", userID, err)
+		}
+		if user.TeamName != teamName {
+			return nil, ErrUserOutsideTeam
 		}
+		users[userID] = user
 	}
+	return users, nil
+}
 
+// checkPlan returns ErrPlanStale unless the open reviews of the users to deactivate are exactly the ones
+// the plan covers and every planned replacement can still take a review it is not assigned to
+func checkPlan(repos repository.Repositories, plan *domain.BulkDeactivatePlan) error {
+	openPRs, err := repos.PullRequests.GetOpenPRsByReviewers(plan.UserIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get open PRs: %w", err)
+	}
+
+	// Reviews are keyed by PR and reviewer
+	reviewers := make(map[string][]string, len(openPRs))
+	slots := 0
+	for _, pr := range openPRs {
+		reviewers[pr.PullRequestID] = pr.AssignedReviewers
+		for _, reviewerID := range pr.AssignedReviewers {
+			if containsString(plan.UserIDs, reviewerID) {
+				slots++
+			}
+		}
+	}
+	if slots != len(plan.Steps) {
+		return ErrPlanStale
+	}
+
+	for _, step := range plan.Steps {
+		assigned, ok := reviewers[step.PullRequestID]
+		if !ok || !containsString(assigned, step.OldUserID) {
+			return ErrPlanStale
+		}
+		if step.Replacement == nil {
+			continue
+		}
+
+		if containsString(assigned, step.Replacement.UserID) {
+			return ErrPlanStale
+		}
+		replacement, err := repos.Users.GetUser(step.Replacement.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPlanStale
+			}
+			return fmt.Errorf("failed to get replacement %s: %w", step.Replacement.UserID, err)
+		}
+		if !replacement.IsActive || replacement.IsAbsent {
+			return ErrPlanStale
+		}
+	}
 	return nil
 }
+
+// applyStep replaces or, without a replacement, removes the deactivated reviewer of a step
+func applyStep(repos repository.Repositories, step domain.DeactivationStep, actor string) error {
+	if step.Replacement == nil {
+		err := repos.PullRequests.RemoveReviewer(step.PullRequestID, step.OldUserID, domain.AssignmentChange{
+			Actor:  actor,
+			Reason: "reviewer deactivated, no replacement",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove reviewer %s from PR %s: %w", step.OldUserID, step.PullRequestID, err)
+		}
+		return nil
+	}
+
+	err := repos.PullRequests.ReassignReviewer(
+		step.PullRequestID, step.OldUserID, *step.Replacement, step.IsFallback, domain.AssignmentChange{
+			Type:   domain.AssignmentEventBulkDeactivate,
+			Actor:  actor,
+			Reason: "reviewer deactivated",
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reassign reviewer %s on PR %s: %w", step.OldUserID, step.PullRequestID, err)
+	}
+	return nil
+}
+
+// newBulkDeactivateResult reports the steps of a bulk deactivation
+func newBulkDeactivateResult(
+	teamName string,
+	userIDs []string,
+	steps []domain.DeactivationStep,
+) *domain.BulkDeactivateResult {
+	result := &domain.BulkDeactivateResult{
+		TeamName:         teamName,
+		DeactivatedUsers: userIDs,
+		AffectedPRs:      []string{},
+		Replacements:     []domain.ReviewerReplacement{},
+		UnfilledSlots:    []domain.UnfilledSlot{},
+	}
+
+	for _, step := range steps {
+		if !containsString(result.AffectedPRs, step.PullRequestID) {
+			result.AffectedPRs = append(result.AffectedPRs, step.PullRequestID)
+		}
+
+		if step.Replacement == nil {
+			result.UnfilledSlots = append(result.UnfilledSlots, domain.UnfilledSlot{
+				PullRequestID: step.PullRequestID,
+				UserID:        step.OldUserID,
+				Reason:        step.Reason,
+			})
+			continue
+		}
+		result.Replacements = append(result.Replacements, domain.ReviewerReplacement{
+			PullRequestID: step.PullRequestID,
+			OldUserID:     step.OldUserID,
+			NewUserID:     step.Replacement.UserID,
+		})
+	}
+
+	return result
+}
+
+// plannedReviews is a PR repository that reports open review counts and recent pairings as they would be
+// after the recorded steps, so that a dry run sees its earlier picks without writing them
+type plannedReviews struct {
+	repository.PullRequestRepository
+	// openReviews holds the change of open reviews by team name and user ID
+	openReviews map[string]map[string]int
+	pairings    []plannedPairing
+}
+
+// plannedPairing is a review of a PR of the author gained (delta 1) or lost (delta -1) by a planned step
+type plannedPairing struct {
+	authorID  string
+	createdAt *time.Time
+	userID    string
+	delta     int
+}
+
+func newPlannedReviews(prRepo repository.PullRequestRepository) *plannedReviews {
+	return &plannedReviews{
+		PullRequestRepository: prRepo,
+		openReviews:           make(map[string]map[string]int),
+	}
+}
+
+// record counts the review of the step as moved from the old reviewer to the replacement, if any
+func (r *plannedReviews) record(
+	userRepo repository.UserRepository,
+	pr *domain.PullRequest,
+	oldReviewer *domain.User,
+	step domain.DeactivationStep,
+) error {
+	r.move(pr, oldReviewer.TeamName, oldReviewer.UserID, -1)
+	if step.Replacement == nil {
+		return nil
+	}
+
+	replacement, err := userRepo.GetUser(step.Replacement.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get replacement %s: %w", step.Replacement.UserID, err)
+	}
+	r.move(pr, replacement.TeamName, replacement.UserID, 1)
+	return nil
+}
+
+func (r *plannedReviews) move(pr *domain.PullRequest, teamName string, userID string, delta int) {
+	if r.openReviews[teamName] == nil {
+		r.openReviews[teamName] = make(map[string]int)
+	}
+	r.openReviews[teamName][userID] += delta
+	r.pairings = append(r.pairings, plannedPairing{
+		authorID:  pr.AuthorID,
+		createdAt: pr.CreatedAt,
+		userID:    userID,
+		delta:     delta,
+	})
+}
+
+func (r *plannedReviews) GetOpenReviewCountsByTeam(teamName string) (map[string]int, error) {
+	stored, err := r.PullRequestRepository.GetOpenReviewCountsByTeam(teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(stored))
+	for userID, count := range stored {
+		counts[userID] = count
+	}
+	for userID, delta := range r.openReviews[teamName] {
+		counts[userID] += delta
+	}
+	return counts, nil
+}
+
+func (r *plannedReviews) GetRecentPairings(authorID string, since time.Time) (map[string]int, error) {
+	stored, err := r.PullRequestRepository.GetRecentPairings(authorID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	pairings := make(map[string]int, len(stored))
+	for userID, count := range stored {
+		pairings[userID] = count
+	}
+	for _, pairing := range r.pairings {
+		if pairing.authorID == authorID && pairing.createdAt != nil && !pairing.createdAt.Before(since) {
+			pairings[pairing.userID] += pairing.delta
+		}
+	}
+	// Like the repository, only users with pairings are listed
+	for userID, count := range pairings {
+		if count <= 0 {
+			delete(pairings, userID)
+		}
+	}
+	return pairings, nil
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTransactor runs work on the given repositories and records whether it would be committed
+type fakeTransactor struct {
+	repos     repository.Repositories
+	committed bool
+}
+
+func (t *fakeTransactor) WithinTx(fn func(repos repository.Repositories) error) error {
+	err := fn(t.repos)
+	t.committed = err == nil
+	return err
+}
+
+type MockDeactivationPlanRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeactivationPlanRepository) CreatePlan(plan *domain.BulkDeactivatePlan) error {
+	args := m.Called(plan)
+	return args.Error(0)
+}
+
+func (m *MockDeactivationPlanRepository) GetPlan(planID int64) (*domain.BulkDeactivatePlan, error) {
+	args := m.Called(planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BulkDeactivatePlan), args.Error(1)
+}
+
+func (m *MockDeactivationPlanRepository) MarkPlanExecuted(planID int64, executedAt time.Time) error {
+	args := m.Called(planID, executedAt)
+	return args.Error(0)
+}
+
+func newBulkDeactivateTest() (
+	*BulkDeactivateService,
+	*fakeTransactor,
+	*MockPullRequestRepository,
+	*MockUserRepository,
+	*MockTeamRepository,
+	*MockDeactivationPlanRepository,
+) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	mockPlanRepo := new(MockDeactivationPlanRepository)
+	transactor := &fakeTransactor{repos: repository.Repositories{
+		Teams:        mockTeamRepo,
+		Users:        mockUserRepo,
+		PullRequests: mockPRRepo,
+		Plans:        mockPlanRepo,
+	}}
+	prService := NewPullRequestService(
+		nil, new(MockPullRequestRepository), new(MockUserRepository), new(MockTeamRepository),
+	)
+	service := NewBulkDeactivateService(transactor, mockPlanRepo, prService)
+	return service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, mockPlanRepo
+}
+
+// newDeactivationPlan returns a stored plan deactivating u2 and u3 of pr-1: u4 replaces u2, nobody replaces u3
+func newDeactivationPlan() *domain.BulkDeactivatePlan {
+	return &domain.BulkDeactivatePlan{
+		PlanID:   7,
+		TeamName: "backend",
+		UserIDs:  []string{"u2", "u3"},
+		Steps: []domain.DeactivationStep{
+			{
+				PullRequestID: "pr-1",
+				OldUserID:     "u2",
+				Replacement:   &domain.ReviewerSelection{UserID: "u4", Strategy: domain.ReviewerStrategyRandom, Seed: 42},
+			},
+			{PullRequestID: "pr-1", OldUserID: "u3", Reason: ErrNoCandidate.Error()},
+		},
+	}
+}
+
+func TestBulkDeactivateService_BulkDeactivate(t *testing.T) {
+	service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, _ := newBulkDeactivateTest()
+
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u3").Return(&domain.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2", "u3"}).Return([]*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}},
+	}, nil)
+	mockUserRepo.On("BulkSetIsActive", []string{"u2", "u3"}, false).Return(nil)
+
+	// u2 is replaced by u4, then nobody is left for u3
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u3", "u2", "u3"}).Return([]*domain.User{
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}, nil).Once()
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u3", "u4", "u3"}).
+		Return([]*domain.User{}, nil).Once()
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4", false, domain.AssignmentChange{
+		Type:   domain.AssignmentEventBulkDeactivate,
+		Actor:  "alice",
+		Reason: "reviewer deactivated",
+	}).Return(nil)
+	mockPRRepo.On("RemoveReviewer", "pr-1", "u3", domain.AssignmentChange{
+		Actor:  "alice",
+		Reason: "reviewer deactivated, no replacement",
+	}).Return(nil)
+
+	result, err := service.BulkDeactivate("backend", []string{"u2", "u3"}, "alice")
+	assert.NoError(t, err)
+	assert.True(t, transactor.committed)
+	assert.Equal(t, []string{"pr-1"}, result.AffectedPRs)
+	assert.Equal(t, []domain.ReviewerReplacement{
+		{PullRequestID: "pr-1", OldUserID: "u2", NewUserID: "u4"},
+	}, result.Replacements)
+	assert.Equal(t, []domain.UnfilledSlot{
+		{PullRequestID: "pr-1", UserID: "u3", Reason: ErrNoCandidate.Error()},
+	}, result.UnfilledSlots)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestBulkDeactivateService_BulkDeactivate_UrgentPRGoesToLeastLoaded(t *testing.T) {
+	service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, _ := newBulkDeactivateTest()
+
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]*domain.PullRequest{
+		{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			Priority:          domain.PRPriorityUrgent,
+			AssignedReviewers: []string{"u2"},
+		},
+	}, nil)
+	mockUserRepo.On("BulkSetIsActive", []string{"u2"}, false).Return(nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u2"}).Return([]*domain.User{
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}, nil)
+	// The team picks at random, an urgent PR goes to the least loaded member instead
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{"u3": 3, "u4": 0}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4", false, mock.Anything).Return(nil)
+
+	result, err := service.BulkDeactivate("backend", []string{"u2"}, "alice")
+	assert.NoError(t, err)
+	assert.True(t, transactor.committed)
+	assert.Equal(t, []domain.ReviewerReplacement{
+		{PullRequestID: "pr-1", OldUserID: "u2", NewUserID: "u4"},
+	}, result.Replacements)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestBulkDeactivateService_BulkDeactivate_UserOutsideTeam(t *testing.T) {
+	service, transactor, _, mockUserRepo, mockTeamRepo, _ := newBulkDeactivateTest()
+
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockUserRepo.On("GetUser", "m1").Return(&domain.User{UserID: "m1", TeamName: "mobile", IsActive: true}, nil)
+
+	_, err := service.BulkDeactivate("backend", []string{"m1"}, "alice")
+	assert.ErrorIs(t, err, ErrUserOutsideTeam)
+	assert.False(t, transactor.committed)
+	mockUserRepo.AssertNotCalled(t, "BulkSetIsActive", mock.Anything, mock.Anything)
+}
+
+func TestBulkDeactivateService_BulkDeactivate_RollsBackOnFailure(t *testing.T) {
+	service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, _ := newBulkDeactivateTest()
+
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+	}, nil)
+	mockUserRepo.On("BulkSetIsActive", []string{"u2"}, false).Return(nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u2"}).Return([]*domain.User{
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4", false, mock.Anything).Return(errors.New("connection reset"))
+
+	_, err := service.BulkDeactivate("backend", []string{"u2"}, "alice")
+	assert.Error(t, err)
+	assert.False(t, transactor.committed)
+}
+
+func TestBulkDeactivateService_PlanBulkDeactivate(t *testing.T) {
+	service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, mockPlanRepo := newBulkDeactivateTest()
+
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u2"}).Return([]*domain.User{
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetUser", "u4").Return(&domain.User{UserID: "u4", TeamName: "backend", IsActive: true}, nil)
+	mockPlanRepo.On("CreatePlan", mock.MatchedBy(func(plan *domain.BulkDeactivatePlan) bool {
+		return plan.TeamName == "backend" && len(plan.Steps) == 1 && plan.Steps[0].Replacement.UserID == "u4"
+	})).Run(func(args mock.Arguments) {
+		args.Get(0).(*domain.BulkDeactivatePlan).PlanID = 7
+	}).Return(nil)
+
+	result, err := service.PlanBulkDeactivate("backend", []string{"u2"})
+	assert.NoError(t, err)
+	assert.True(t, transactor.committed)
+	assert.Equal(t, int64(7), result.PlanID)
+	assert.Equal(t, []domain.ReviewerReplacement{
+		{PullRequestID: "pr-1", OldUserID: "u2", NewUserID: "u4"},
+	}, result.Replacements)
+
+	// A dry run only reads
+	mockUserRepo.AssertNotCalled(t, "BulkSetIsActive", mock.Anything, mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "ReassignReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockPlanRepo.AssertExpectations(t)
+}
+
+func TestBulkDeactivateService_PlanBulkDeactivate_SeesEarlierPicks(t *testing.T) {
+	service, _, mockPRRepo, mockUserRepo, mockTeamRepo, mockPlanRepo := newBulkDeactivateTest()
+
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyLeastLoaded,
+	}, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u4").Return(&domain.User{UserID: "u4", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u5").Return(&domain.User{UserID: "u5", TeamName: "backend", IsActive: true}, nil)
+	// The repository lists PRs in no particular order
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2"}).Return([]*domain.PullRequest{
+		{PullRequestID: "pr-2", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", mock.Anything).Return([]*domain.User{
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+		{UserID: "u5", TeamName: "backend", IsActive: true},
+	}, nil)
+	// u4 and u5 are equally loaded: whoever takes pr-1 is more loaded when pr-2 is planned
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{"u2": 2, "u4": 0, "u5": 0}, nil)
+	mockPlanRepo.On("CreatePlan", mock.Anything).Return(nil)
+
+	roundRobin := service.prService.selectors[domain.ReviewerStrategyRoundRobin].(*RoundRobinSelector)
+
+	result, err := service.PlanBulkDeactivate("backend", []string{"u2"})
+	assert.NoError(t, err)
+	require.Len(t, result.Replacements, 2)
+	assert.Equal(t, []string{"pr-1", "pr-2"}, result.AffectedPRs)
+	assert.Equal(t, "pr-1", result.Replacements[0].PullRequestID)
+	assert.NotEqual(t, result.Replacements[0].NewUserID, result.Replacements[1].NewUserID)
+	assert.Empty(t, roundRobin.cursor)
+}
+
+func TestBulkDeactivateService_ExecutePlan(t *testing.T) {
+	service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, mockPlanRepo := newBulkDeactivateTest()
+
+	mockPlanRepo.On("GetPlan", int64(7)).Return(newDeactivationPlan(), nil)
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u3").Return(&domain.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u4").Return(&domain.User{UserID: "u4", TeamName: "backend", IsActive: true}, nil)
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2", "u3"}).Return([]*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2", "u3"}},
+	}, nil)
+	mockUserRepo.On("BulkSetIsActive", []string{"u2", "u3"}, false).Return(nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u4", false, domain.AssignmentChange{
+		Type:   domain.AssignmentEventBulkDeactivate,
+		Actor:  "alice",
+		Reason: "reviewer deactivated",
+	}).Return(nil)
+	mockPRRepo.On("RemoveReviewer", "pr-1", "u3", domain.AssignmentChange{
+		Actor:  "alice",
+		Reason: "reviewer deactivated, no replacement",
+	}).Return(nil)
+	mockPlanRepo.On("MarkPlanExecuted", int64(7), mock.AnythingOfType("time.Time")).Return(nil)
+
+	result, err := service.ExecutePlan(7, "alice")
+	assert.NoError(t, err)
+	assert.True(t, transactor.committed)
+	assert.Equal(t, int64(7), result.PlanID)
+	assert.Equal(t, []domain.UnfilledSlot{
+		{PullRequestID: "pr-1", UserID: "u3", Reason: ErrNoCandidate.Error()},
+	}, result.UnfilledSlots)
+
+	// The replacement comes from the plan, no candidates are picked again
+	mockUserRepo.AssertNotCalled(t, "GetActiveUsersByTeam", mock.Anything, mock.Anything)
+	mockPRRepo.AssertExpectations(t)
+	mockPlanRepo.AssertExpectations(t)
+}
+
+func TestBulkDeactivateService_ExecutePlan_Stale(t *testing.T) {
+	service, transactor, mockPRRepo, mockUserRepo, mockTeamRepo, mockPlanRepo := newBulkDeactivateTest()
+
+	mockPlanRepo.On("GetPlan", int64(7)).Return(newDeactivationPlan(), nil)
+	mockTeamRepo.On("TeamExists", "backend").Return(true, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u3").Return(&domain.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+	// u3 was removed from pr-1 after the plan was computed
+	mockPRRepo.On("GetOpenPRsByReviewers", []string{"u2", "u3"}).Return([]*domain.PullRequest{
+		{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, AssignedReviewers: []string{"u2"}},
+	}, nil)
+
+	_, err := service.ExecutePlan(7, "alice")
+	assert.ErrorIs(t, err, ErrPlanStale)
+	assert.False(t, transactor.committed)
+	mockUserRepo.AssertNotCalled(t, "BulkSetIsActive", mock.Anything, mock.Anything)
+}
+
+func TestBulkDeactivateService_ExecutePlan_AlreadyExecuted(t *testing.T) {
+	service, _, _, _, mockTeamRepo, mockPlanRepo := newBulkDeactivateTest()
+
+	plan := newDeactivationPlan()
+	executedAt := time.Now()
+	plan.ExecutedAt = &executedAt
+	mockPlanRepo.On("GetPlan", int64(7)).Return(plan, nil)
+
+	_, err := service.ExecutePlan(7, "alice")
+	assert.ErrorIs(t, err, ErrPlanExecuted)
+	mockTeamRepo.AssertNotCalled(t, "TeamExists", mock.Anything)
+}
@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	// ErrInvalidJob is returned when a job has no items or one of its items is incomplete
+	ErrInvalidJob = errors.New("invalid job items")
+	// ErrJobLeaseLost is returned when another worker took a job over after its lease expired
+	ErrJobLeaseLost = errors.New("job lease lost")
+)
+
+// BulkDeactivateJobItem deactivates users of one team, or executes a plan computed by a dry run
+type BulkDeactivateJobItem struct {
+	TeamName string   `json:"team_name,omitempty"`
+	UserIDs  []string `json:"user_ids,omitempty"`
+	PlanID   int64    `json:"plan_id,omitempty"`
+}
+
+// MassReassignJob moves every open review of the users to other reviewers
+type MassReassignJob struct {
+	UserIDs []string `json:"user_ids"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// jobItem is one unit of work of a job; items of a job are processed one after another and
+// a failed item does not stop the job
+type jobItem struct {
+	key string
+	// run applies the item on repos bound to the transaction that records the item as processed,
+	// so an item is either applied and recorded or neither
+	run func(repos repository.Repositories) error
+}
+
+// JobService queues bulk operations and processes them item by item on the worker pool
+type JobService struct {
+	transactor            repository.Transactor
+	jobRepo               repository.JobRepository
+	teamService           *TeamService
+	bulkDeactivateService *BulkDeactivateService
+	prService             *PullRequestService
+}
+
+func NewJobService(
+	transactor repository.Transactor,
+	jobRepo repository.JobRepository,
+	teamService *TeamService,
+	bulkDeactivateService *BulkDeactivateService,
+	prService *PullRequestService,
+) *JobService {
+	return &JobService{
+		transactor:            transactor,
+		jobRepo:               jobRepo,
+		teamService:           teamService,
+		bulkDeactivateService: bulkDeactivateService,
+		prService:             prService,
+	}
+}
+
+// EnqueueBulkDeactivate queues a job running one bulk deactivation per item; each item is applied
+// in its own transaction
+func (s *JobService) EnqueueBulkDeactivate(items []BulkDeactivateJobItem, actor string) (*domain.Job, error) {
+	if len(items) == 0 {
+		return nil, ErrInvalidJob
+	}
+	for _, item := range items {
+		if item.PlanID == 0 && (item.TeamName == "" || len(item.UserIDs) == 0) {
+			return nil, ErrInvalidJob
+		}
+	}
+	return s.enqueue(domain.JobTypeBulkDeactivate, items, len(items), actor)
+}
+
+// EnqueueTeamImport queues a job creating the teams with their members, one team per item
+func (s *JobService) EnqueueTeamImport(teams []domain.Team, actor string) (*domain.Job, error) {
+	if len(teams) == 0 {
+		return nil, ErrInvalidJob
+	}
+	for _, team := range teams {
+		if strings.TrimSpace(team.TeamName) == "" {
+			return nil, ErrInvalidJob
+		}
+	}
+	return s.enqueue(domain.JobTypeTeamImport, teams, len(teams), actor)
+}
+
+// EnqueueMassReassign queues a job reassigning the open reviews of the users, one user per item
+func (s *JobService) EnqueueMassReassign(job MassReassignJob, actor string) (*domain.Job, error) {
+	if len(job.UserIDs) == 0 {
+		return nil, ErrInvalidJob
+	}
+	for _, userID := range job.UserIDs {
+		if strings.TrimSpace(userID) == "" {
+			return nil, ErrInvalidJob
+		}
+	}
+	return s.enqueue(domain.JobTypeMassReassign, job, len(job.UserIDs), actor)
+}
+
+func (s *JobService) enqueue(jobType domain.JobType, payload interface{}, total int, actor string) (*domain.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job := &domain.Job{
+		Type:       jobType,
+		Actor:      actor,
+		Payload:    data,
+		TotalItems: total,
+		Errors:     []domain.JobItemError{},
+	}
+	if err := s.jobRepo.CreateJob(job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return job, nil
+}
+
+// GetJob retrieves a job with its progress and the errors of its failed items
+func (s *JobService) GetJob(jobID int64) (*domain.Job, error) {
+	job, err := s.jobRepo.GetJob(jobID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job, nil
+}
+
+// RunNext claims the next job under the lease and processes its remaining items, reporting whether
+// there was a job to run. An item is recorded as processed in the transaction that applies it, so a job
+// abandoned by a worker that stopped is resumed once its lease expires from the first item that was not
+// applied, and no item is applied twice: a worker that lost the lease while running an item cannot record
+// it, rolls it back and stops with ErrJobLeaseLost. When ctx is cancelled between items the job is put back
+// in the queue
+func (s *JobService) RunNext(ctx context.Context, lease time.Duration) (bool, error) {
+	job, err := s.jobRepo.ClaimJob(lease)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	items, err := s.jobItems(job)
+	if err != nil {
+		if err := s.jobRepo.FinishJob(job.JobID, domain.JobStatusFailed, err.Error()); err != nil {
+			return true, fmt.Errorf("failed to finish job %d: %w", job.JobID, err)
+		}
+		return true, nil
+	}
+
+	// A single item may outlast the lease, so it is extended in the background while the job runs
+	stopHeartbeat := s.keepLease(job.JobID, lease)
+	defer stopHeartbeat()
+
+	failed := job.FailedItems
+	for position := job.ProcessedItems; position < len(items); position++ {
+		if ctx.Err() != nil {
+			if err := s.jobRepo.ReleaseJob(job.JobID); err != nil {
+				return true, fmt.Errorf("failed to release job %d: %w", job.JobID, err)
+			}
+			return true, nil
+		}
+
+		var itemErr error
+		err := s.transactor.WithinTx(func(repos repository.Repositories) error {
+			if itemErr = runJobItem(items[position], repos); itemErr != nil {
+				return itemErr
+			}
+			return repos.Jobs.RecordProgress(job.JobID, position, failed, nil, lease)
+		})
+		if itemErr != nil {
+			// The changes of the item are rolled back, so the failure is recorded on its own
+			failed++
+			err = s.jobRepo.RecordProgress(job.JobID, position, failed, &domain.JobItemError{
+				Item:    items[position].key,
+				Message: itemErr.Error(),
+			}, lease)
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return true, fmt.Errorf("job %d: %w", job.JobID, ErrJobLeaseLost)
+		}
+		if err != nil {
+			return true, fmt.Errorf("failed to record progress of job %d: %w", job.JobID, err)
+		}
+	}
+
+	if err := s.jobRepo.FinishJob(job.JobID, domain.JobStatusCompleted, ""); err != nil {
+		return true, fmt.Errorf("failed to finish job %d: %w", job.JobID, err)
+	}
+	return true, nil
+}
+
+// keepLease extends the lease of the job every third of it until the returned function is called
+func (s *JobService) keepLease(jobID int64, lease time.Duration) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// A missed extension is retried on the next tick
+				if err := s.jobRepo.ExtendLease(jobID, lease); err != nil {
+					slog.Error("Failed to extend job lease", "job_id", jobID, "error", err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// runJobItem runs an item on repos, turning a panic into an error of the item
+func runJobItem(item jobItem, repos repository.Repositories) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return item.run(repos)
+}
+
+// jobItems decodes the payload of the job into its items, in the same order on every call
+func (s *JobService) jobItems(job *domain.Job) ([]jobItem, error) {
+	switch job.Type {
+	case domain.JobTypeBulkDeactivate:
+		var payload []BulkDeactivateJobItem
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("malformed job payload: %w", err)
+		}
+		return s.bulkDeactivateItems(payload, job.Actor), nil
+	case domain.JobTypeTeamImport:
+		var payload []domain.Team
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("malformed job payload: %w", err)
+		}
+		return s.teamImportItems(payload), nil
+	case domain.JobTypeMassReassign:
+		var payload MassReassignJob
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("malformed job payload: %w", err)
+		}
+		return s.massReassignItems(payload, job.Actor), nil
+	default:
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+}
+
+func (s *JobService) bulkDeactivateItems(payload []BulkDeactivateJobItem, actor string) []jobItem {
+	items := make([]jobItem, 0, len(payload))
+	for _, item := range payload {
+		item := item
+		if item.PlanID != 0 {
+			items = append(items, jobItem{
+				key: "plan " + strconv.FormatInt(item.PlanID, 10),
+				run: func(repos repository.Repositories) error {
+					_, err := s.bulkDeactivateService.executePlan(repos, item.PlanID, actor)
+					return err
+				},
+			})
+			continue
+		}
+		items = append(items, jobItem{
+			key: item.TeamName,
+			run: func(repos repository.Repositories) error {
+				_, err := s.bulkDeactivateService.deactivate(repos, item.TeamName, item.UserIDs, actor)
+				return err
+			},
+		})
+	}
+	return items
+}
+
+func (s *JobService) teamImportItems(teams []domain.Team) []jobItem {
+	items := make([]jobItem, 0, len(teams))
+	for i := range teams {
+		team := &teams[i]
+		items = append(items, jobItem{
+			key: team.TeamName,
+			run: func(repos repository.Repositories) error {
+				return s.teamService.withRepositories(repos).CreateTeam(team)
+			},
+		})
+	}
+	return items
+}
+
+// massReassignItems reassigns the open reviews of one user per item. Every review is tried, and the item
+// fails with the errors of the reviews that could not be reassigned. Each reassignment is committed on its
+// own rather than with the item's progress: the item is idempotent, as running it again finds only the
+// reviews the user still has
+func (s *JobService) massReassignItems(payload MassReassignJob, actor string) []jobItem {
+	change := domain.AssignmentChange{Actor: actor, Reason: payload.Reason}
+
+	items := make([]jobItem, 0, len(payload.UserIDs))
+	for _, userID := range payload.UserIDs {
+		userID := userID
+		items = append(items, jobItem{
+			key: userID,
+			run: func(repository.Repositories) error {
+				prs, err := s.prService.GetOpenPRsByReviewer(userID)
+				if err != nil {
+					return err
+				}
+
+				var errs []error
+				for _, pr := range prs {
+					if _, _, err := s.prService.ReassignReviewer(pr.PullRequestID, userID, change); err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", pr.PullRequestID, err))
+					}
+				}
+				return errors.Join(errs...)
+			},
+		})
+	}
+	return items
+}
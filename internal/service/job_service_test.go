@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testJobLease is long enough for the lease never to be extended during a test
+const testJobLease = time.Hour
+
+type MockJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockJobRepository) CreateJob(job *domain.Job) error {
+	args := m.Called(job)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) GetJob(jobID int64) (*domain.Job, error) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) ClaimJob(lease time.Duration) (*domain.Job, error) {
+	args := m.Called(lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *MockJobRepository) ExtendLease(jobID int64, lease time.Duration) error {
+	args := m.Called(jobID, lease)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) RecordProgress(
+	jobID int64,
+	position int,
+	failed int,
+	itemErr *domain.JobItemError,
+	lease time.Duration,
+) error {
+	args := m.Called(jobID, position, failed, itemErr, lease)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) FinishJob(jobID int64, status domain.JobStatus, errMsg string) error {
+	args := m.Called(jobID, status, errMsg)
+	return args.Error(0)
+}
+
+func (m *MockJobRepository) ReleaseJob(jobID int64) error {
+	args := m.Called(jobID)
+	return args.Error(0)
+}
+
+// newJobTransactor returns a transactor running job items and their progress on the mocks
+func newJobTransactor(mockJobRepo *MockJobRepository, mockTeamRepo *MockTeamRepository) *fakeTransactor {
+	return &fakeTransactor{repos: repository.Repositories{Teams: mockTeamRepo, Jobs: mockJobRepo}}
+}
+
+// newTeamImportJob returns a claimed job importing teams a and b
+func newTeamImportJob(t *testing.T) *domain.Job {
+	payload, err := json.Marshal([]domain.Team{{TeamName: "a"}, {TeamName: "b"}})
+	assert.NoError(t, err)
+	return &domain.Job{
+		JobID:      5,
+		Type:       domain.JobTypeTeamImport,
+		Status:     domain.JobStatusRunning,
+		Actor:      "alice",
+		Payload:    payload,
+		TotalItems: 2,
+	}
+}
+
+func TestJobService_EnqueueTeamImport(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	service := NewJobService(&fakeTransactor{}, mockJobRepo, NewTeamService(new(MockTeamRepository)), nil, nil)
+
+	_, err := service.EnqueueTeamImport(nil, "alice")
+	assert.ErrorIs(t, err, ErrInvalidJob)
+	_, err = service.EnqueueTeamImport([]domain.Team{{TeamName: " "}}, "alice")
+	assert.ErrorIs(t, err, ErrInvalidJob)
+
+	mockJobRepo.On("CreateJob", mock.MatchedBy(func(job *domain.Job) bool {
+		return job.Type == domain.JobTypeTeamImport && job.TotalItems == 2 && job.Actor == "alice"
+	})).Return(nil)
+
+	job, err := service.EnqueueTeamImport([]domain.Team{{TeamName: "a"}, {TeamName: "b"}}, "alice")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"team_name":"a","members":null},{"team_name":"b","members":null}]`, string(job.Payload))
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestJobService_RunNext_RecordsItemErrors(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewJobService(newJobTransactor(mockJobRepo, mockTeamRepo), mockJobRepo, NewTeamService(mockTeamRepo), nil, nil)
+
+	mockJobRepo.On("ClaimJob", testJobLease).Return(newTeamImportJob(t), nil)
+	mockTeamRepo.On("TeamExists", "a").Return(false, nil)
+	mockTeamRepo.On("CreateTeam", mock.AnythingOfType("*domain.Team")).Return(nil)
+	mockTeamRepo.On("TeamExists", "b").Return(true, nil)
+	mockJobRepo.On("RecordProgress", int64(5), 0, 0, (*domain.JobItemError)(nil), testJobLease).Return(nil)
+	mockJobRepo.On("RecordProgress", int64(5), 1, 1, &domain.JobItemError{
+		Item:    "b",
+		Message: ErrTeamExists.Error(),
+	}, testJobLease).Return(nil)
+	mockJobRepo.On("FinishJob", int64(5), domain.JobStatusCompleted, "").Return(nil)
+
+	ran, err := service.RunNext(context.Background(), testJobLease)
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestJobService_RunNext_ResumesAfterProcessedItems(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewJobService(newJobTransactor(mockJobRepo, mockTeamRepo), mockJobRepo, NewTeamService(mockTeamRepo), nil, nil)
+
+	// Team a was imported before the server restarted
+	job := newTeamImportJob(t)
+	job.ProcessedItems = 1
+	mockJobRepo.On("ClaimJob", testJobLease).Return(job, nil)
+	mockTeamRepo.On("TeamExists", "b").Return(false, nil)
+	mockTeamRepo.On("CreateTeam", mock.AnythingOfType("*domain.Team")).Return(nil)
+	mockJobRepo.On("RecordProgress", int64(5), 1, 0, (*domain.JobItemError)(nil), testJobLease).Return(nil)
+	mockJobRepo.On("FinishJob", int64(5), domain.JobStatusCompleted, "").Return(nil)
+
+	ran, err := service.RunNext(context.Background(), testJobLease)
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	mockTeamRepo.AssertNotCalled(t, "TeamExists", "a")
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestJobService_RunNext_RollsBackItemWithoutProgress(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	transactor := newJobTransactor(mockJobRepo, mockTeamRepo)
+	service := NewJobService(transactor, mockJobRepo, NewTeamService(mockTeamRepo), nil, nil)
+
+	// Team a is created, but recording it fails: the team must not stay created without its progress
+	mockJobRepo.On("ClaimJob", testJobLease).Return(newTeamImportJob(t), nil)
+	mockTeamRepo.On("TeamExists", "a").Return(false, nil)
+	mockTeamRepo.On("CreateTeam", mock.AnythingOfType("*domain.Team")).Return(nil)
+	mockJobRepo.On("RecordProgress", int64(5), 0, 0, (*domain.JobItemError)(nil), testJobLease).
+		Return(errors.New("connection reset"))
+
+	ran, err := service.RunNext(context.Background(), testJobLease)
+	assert.Error(t, err)
+	assert.True(t, ran)
+	assert.False(t, transactor.committed)
+	mockTeamRepo.AssertNotCalled(t, "TeamExists", "b")
+	mockJobRepo.AssertNotCalled(t, "FinishJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJobService_RunNext_StopsWhenLeaseIsLost(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	transactor := newJobTransactor(mockJobRepo, mockTeamRepo)
+	service := NewJobService(transactor, mockJobRepo, NewTeamService(mockTeamRepo), nil, nil)
+
+	// Another worker claimed the job after the lease expired and already recorded team a
+	mockJobRepo.On("ClaimJob", testJobLease).Return(newTeamImportJob(t), nil)
+	mockTeamRepo.On("TeamExists", "a").Return(false, nil)
+	mockTeamRepo.On("CreateTeam", mock.AnythingOfType("*domain.Team")).Return(nil)
+	mockJobRepo.On("RecordProgress", int64(5), 0, 0, (*domain.JobItemError)(nil), testJobLease).
+		Return(repository.ErrNotFound)
+
+	ran, err := service.RunNext(context.Background(), testJobLease)
+	assert.ErrorIs(t, err, ErrJobLeaseLost)
+	assert.True(t, ran)
+	assert.False(t, transactor.committed)
+	mockTeamRepo.AssertNotCalled(t, "TeamExists", "b")
+	mockJobRepo.AssertNotCalled(t, "FinishJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJobService_RunNext_ReleasesJobOnShutdown(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewJobService(newJobTransactor(mockJobRepo, mockTeamRepo), mockJobRepo, NewTeamService(mockTeamRepo), nil, nil)
+
+	mockJobRepo.On("ClaimJob", testJobLease).Return(newTeamImportJob(t), nil)
+	mockJobRepo.On("ReleaseJob", int64(5)).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ran, err := service.RunNext(ctx, testJobLease)
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	mockTeamRepo.AssertNotCalled(t, "CreateTeam", mock.Anything)
+	mockJobRepo.AssertNotCalled(t, "FinishJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJobService_RunNext_NoJob(t *testing.T) {
+	mockJobRepo := new(MockJobRepository)
+	service := NewJobService(&fakeTransactor{}, mockJobRepo, nil, nil, nil)
+
+	mockJobRepo.On("ClaimJob", testJobLease).Return(nil, repository.ErrNotFound)
+
+	ran, err := service.RunNext(context.Background(), testJobLease)
+	assert.NoError(t, err)
+	assert.False(t, ran)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/repository"
+)
+
+// DeletePR removes a PR created by mistake together with its reviewers, reviews and declines.
+// A PR other PRs depend on is kept, as deleting it would unblock their merge
+func (s *PullRequestService) DeletePR(prID string) error {
+	dependents, err := s.prRepo.GetDependents(prID)
+	if err != nil {
+		return fmt.Errorf("failed to get dependent PRs: %w", err)
+	}
+	if len(dependents) > 0 {
+		return ErrPRHasDependents
+	}
+
+	if err := s.prRepo.DeletePR(prID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPRNotFound
+		}
+		return fmt.Errorf("failed to delete PR: %w", err)
+	}
+	return nil
+}
+
+// ArchiveMergedPRs archives PRs merged more than retention ago and returns how many were archived
+func (s *PullRequestService) ArchiveMergedPRs(retention time.Duration) (int, error) {
+	archived, err := s.prRepo.ArchiveMergedPRs(time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive merged PRs: %w", err)
+	}
+	return archived, nil
+}
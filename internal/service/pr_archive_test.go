@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"avito-tech-internship/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_DeletePR(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	mockPRRepo.On("GetDependents", mock.Anything).Return([]string{}, nil).Twice()
+	mockPRRepo.On("DeletePR", "pr-1").Return(nil)
+	mockPRRepo.On("DeletePR", "pr-404").Return(repository.ErrNotFound)
+
+	assert.NoError(t, service.DeletePR("pr-1"))
+	assert.ErrorIs(t, service.DeletePR("pr-404"), ErrPRNotFound)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_DeletePR_WithDependents(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	mockPRRepo.On("GetDependents", "pr-1").Return([]string{"pr-2"}, nil)
+
+	assert.ErrorIs(t, service.DeletePR("pr-1"), ErrPRHasDependents)
+	mockPRRepo.AssertNotCalled(t, "DeletePR", mock.Anything)
+}
+
+func TestPullRequestService_ArchiveMergedPRs(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	retention := 30 * 24 * time.Hour
+	before := time.Now().Add(-retention)
+	mockPRRepo.On("ArchiveMergedPRs", mock.MatchedBy(func(mergedBefore time.Time) bool {
+		return !mergedBefore.Before(before) && mergedBefore.Before(time.Now().Add(-retention+time.Minute))
+	})).Return(3, nil)
+
+	archived, err := service.ArchiveMergedPRs(retention)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, archived)
+
+	mockPRRepo.AssertExpectations(t)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+var (
+	ErrDependencyNotFound = errors.New("dependency PR not found")
+	ErrDependencyCycle    = errors.New("PR dependencies form a cycle")
+	// ErrDependenciesNotMerged is returned when a PR is merged before the PRs it depends on
+	ErrDependenciesNotMerged = errors.New("PR dependencies are not merged")
+	// ErrPRHasDependents is returned when a PR other PRs depend on is deleted
+	ErrPRHasDependents = errors.New("other PRs depend on the PR")
+)
+
+// normalizeDependencies trims and deduplicates IDs of PRs a PR depends on
+func normalizeDependencies(dependsOn []string) []string {
+	if dependsOn == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(dependsOn))
+	for _, prID := range dependsOn {
+		prID = strings.TrimSpace(prID)
+		if prID != "" && !containsString(normalized, prID) {
+			normalized = append(normalized, prID)
+		}
+	}
+	return normalized
+}
+
+// checkDependencies returns an error unless every dependency of the PR exists and none of them
+// depends, directly or through other PRs, on the PR itself
+func (s *PullRequestService) checkDependencies(prID string, dependsOn []string) error {
+	for _, depID := range dependsOn {
+		if depID == prID {
+			return ErrDependencyCycle
+		}
+		exists, err := s.prRepo.PRExists(depID)
+		if err != nil {
+			return fmt.Errorf("failed to check dependency existence: %w", err)
+		}
+		if !exists {
+			return ErrDependencyNotFound
+		}
+	}
+
+	if len(dependsOn) == 0 {
+		return nil
+	}
+
+	ancestors, err := s.prRepo.GetDependencyClosure(dependsOn)
+	if err != nil {
+		return fmt.Errorf("failed to get dependency closure: %w", err)
+	}
+	if containsString(ancestors, prID) {
+		return ErrDependencyCycle
+	}
+	return nil
+}
+
+// checkDependenciesMerged returns ErrDependenciesNotMerged while any PR the PR depends on is not merged
+func (s *PullRequestService) checkDependenciesMerged(pr *domain.PullRequest) error {
+	if len(pr.DependsOn) == 0 {
+		return nil
+	}
+
+	unmerged, err := s.prRepo.GetUnmergedDependencies(pr.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to get unmerged dependencies: %w", err)
+	}
+	if len(unmerged) > 0 {
+		return ErrDependenciesNotMerged
+	}
+	return nil
+}
+
+// pickStackReviewers selects up to count active and present reviewers of the PRs the PR depends on,
+// so that a reviewer who already knows the stack reviews the next PR of it. Like code owners, they are
+// picked regardless of capacity limits
+func (s *PullRequestService) pickStackReviewers(
+	pr *domain.PullRequest,
+	count int,
+	excludeIDs []string,
+) ([]*domain.User, error) {
+	picked := []*domain.User{}
+	for _, depID := range pr.DependsOn {
+		if len(picked) >= count {
+			break
+		}
+
+		dep, err := s.prRepo.GetPR(depID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get dependency PR %s: %w", depID, err)
+		}
+
+		for _, reviewerID := range dep.AssignedReviewers {
+			if len(picked) >= count {
+				break
+			}
+			if containsString(excludeIDs, reviewerID) || containsString(userIDs(picked), reviewerID) {
+				continue
+			}
+
+			reviewer, err := s.userRepo.GetUser(reviewerID)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get reviewer %s: %w", reviewerID, err)
+			}
+			if reviewer.IsActive && !reviewer.IsAbsent {
+				picked = append(picked, reviewer)
+			}
+		}
+	}
+
+	return picked, nil
+}
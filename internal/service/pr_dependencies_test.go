@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPullRequestService_CheckDependencies(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	mockPRRepo.On("PRExists", "pr-a").Return(true, nil)
+	mockPRRepo.On("PRExists", "pr-b").Return(true, nil)
+	mockPRRepo.On("PRExists", "pr-x").Return(false, nil)
+	// pr-b depends on pr-a, which depends on pr-c
+	mockPRRepo.On("GetDependencyClosure", []string{"pr-b"}).Return([]string{"pr-a", "pr-c"}, nil)
+	mockPRRepo.On("GetDependencyClosure", []string{"pr-a"}).Return([]string{"pr-c"}, nil)
+
+	assert.NoError(t, service.checkDependencies("pr-d", []string{"pr-b"}))
+	assert.ErrorIs(t, service.checkDependencies("pr-c", []string{"pr-b"}), ErrDependencyCycle)
+	assert.ErrorIs(t, service.checkDependencies("pr-a", []string{"pr-a"}), ErrDependencyCycle)
+	assert.ErrorIs(t, service.checkDependencies("pr-d", []string{"pr-x"}), ErrDependencyNotFound)
+	assert.NoError(t, service.checkDependencies("pr-d", nil))
+}
+
+func TestNormalizeDependencies(t *testing.T) {
+	assert.Equal(t, []string{"pr-1", "pr-2"}, normalizeDependencies([]string{" pr-1", "pr-2", "pr-1", ""}))
+	assert.Nil(t, normalizeDependencies(nil))
+}
+
+func TestPullRequestService_MergePR_DependenciesNotMerged(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewPullRequestService(newPRTransactor(mockPRRepo, mockUserRepo, mockTeamRepo), mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID: "pr-b",
+		AuthorID:      "u1",
+		Status:        domain.PRStatusOpen,
+		DependsOn:     []string{"pr-a"},
+	}
+	mockPRRepo.On("GetPRForUpdate", "pr-b").Return(pr, nil)
+	mockPRRepo.On("GetUnmergedDependencies", "pr-b").Return([]string{"pr-a"}, nil)
+
+	// Force bypasses approvals, not the merge order
+	_, err := service.MergePR("pr-b", true, "admin")
+	assert.ErrorIs(t, err, ErrDependenciesNotMerged)
+
+	mockPRRepo.AssertNotCalled(t, "MergePR", "pr-b", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_CreatePR_PrefersStackReviewers(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("PRExists", "pr-b").Return(false, nil)
+	mockPRRepo.On("PRExists", "pr-a").Return(true, nil)
+	mockPRRepo.On("GetDependencyClosure", []string{"pr-a"}).Return([]string{}, nil)
+	mockPRRepo.On("GetPR", "pr-a").Return(&domain.PullRequest{
+		PullRequestID:     "pr-a",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusMerged,
+		AssignedReviewers: []string{"u3", "u4"},
+	}, nil)
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u3").Return(&domain.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u4").Return(&domain.User{UserID: "u4", TeamName: "backend", IsActive: false}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u3"}).Return([]*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-b", AuthorID: "u1", DependsOn: []string{"pr-a"}}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3", "u2"}, pr.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"fmt"
+
+	"avito-tech-internship/internal/domain"
+)
+
+// GetAssignmentHistory returns every reviewer assignment change of a PR, oldest first. The history is kept
+// when the PR is deleted, so it is returned for a deleted PR as well
+func (s *PullRequestService) GetAssignmentHistory(prID string) ([]*domain.AssignmentEvent, error) {
+	events, err := s.prRepo.GetAssignmentHistory(prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment history: %w", err)
+	}
+	if len(events) > 0 {
+		return events, nil
+	}
+
+	exists, err := s.prRepo.PRExists(prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check PR existence: %w", err)
+	}
+	if !exists {
+		return nil, ErrPRNotFound
+	}
+	return events, nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullRequestService_GetAssignmentHistory(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	events := []*domain.AssignmentEvent{
+		{EventID: 1, PullRequestID: "pr-1", Type: domain.AssignmentEventAssign, UserID: "u2", Actor: "alice"},
+		{
+			EventID:       2,
+			PullRequestID: "pr-1",
+			Type:          domain.AssignmentEventReassign,
+			UserID:        "u2",
+			ReplacementID: "u3",
+			Actor:         SystemActor,
+			Reason:        "review SLA exceeded",
+		},
+	}
+	// pr-1 may have been deleted, its history is still returned
+	mockPRRepo.On("GetAssignmentHistory", "pr-1").Return(events, nil)
+	mockPRRepo.On("GetAssignmentHistory", "pr-2").Return([]*domain.AssignmentEvent{}, nil)
+	mockPRRepo.On("PRExists", "pr-2").Return(true, nil)
+	mockPRRepo.On("GetAssignmentHistory", "pr-404").Return([]*domain.AssignmentEvent{}, nil)
+	mockPRRepo.On("PRExists", "pr-404").Return(false, nil)
+
+	result, err := service.GetAssignmentHistory("pr-1")
+	assert.NoError(t, err)
+	assert.Equal(t, events, result)
+
+	result, err = service.GetAssignmentHistory("pr-2")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = service.GetAssignmentHistory("pr-404")
+	assert.ErrorIs(t, err, ErrPRNotFound)
+
+	mockPRRepo.AssertExpectations(t)
+	mockPRRepo.AssertNotCalled(t, "PRExists", "pr-1")
+}
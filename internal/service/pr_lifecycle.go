@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+// ErrInvalidTransition is returned when the PR cannot move to the requested status from its current one
+var ErrInvalidTransition = errors.New("illegal PR status transition")
+
+// prTransition is an edge of the PR state machine: a PR in any of the from statuses may move to the to status
+type prTransition struct {
+	from []domain.PRStatus
+	to   domain.PRStatus
+}
+
+// PR state machine:
+//
+//	DRAFT --ready--> OPEN --merge--> MERGED
+//	DRAFT, OPEN --close--> CLOSED --reopen--> OPEN
+var (
+	transitionReady  = prTransition{from: []domain.PRStatus{domain.PRStatusDraft}, to: domain.PRStatusOpen}
+	transitionMerge  = prTransition{from: []domain.PRStatus{domain.PRStatusOpen}, to: domain.PRStatusMerged}
+	transitionClose  = prTransition{from: []domain.PRStatus{domain.PRStatusDraft, domain.PRStatusOpen}, to: domain.PRStatusClosed}
+	transitionReopen = prTransition{from: []domain.PRStatus{domain.PRStatusClosed}, to: domain.PRStatusOpen}
+)
+
+// check reports whether the PR is already in the target status (so the transition is a no-op)
+// and returns an error when the transition is not allowed from the current status
+func (t prTransition) check(status domain.PRStatus) (bool, error) {
+	if status == t.to {
+		return true, nil
+	}
+	for _, from := range t.from {
+		if status == from {
+			return false, nil
+		}
+	}
+	if status == domain.PRStatusMerged {
+		return false, ErrPRMerged
+	}
+	return false, ErrInvalidTransition
+}
+
+// MarkReady moves a DRAFT PR to OPEN and assigns its reviewers the same way CreatePR does
+// (idempotent operation)
+func (s *PullRequestService) MarkReady(prID string, opts CreatePROptions) (*domain.PullRequest, error) {
+	change := domain.AssignmentChange{Actor: opts.Actor, Reason: "PR marked ready"}
+	return s.transition(prID, transitionReady, change, func(pr *domain.PullRequest) error {
+		author, err := s.getAuthor(pr)
+		if err != nil {
+			return err
+		}
+		return s.assignInitialReviewers(pr, author, opts)
+	})
+}
+
+// ClosePR abandons a DRAFT or OPEN PR without merging and releases its reviewers (idempotent operation)
+func (s *PullRequestService) ClosePR(prID string, change domain.AssignmentChange) (*domain.PullRequest, error) {
+	if change.Reason == "" {
+		change.Reason = "PR closed"
+	}
+	return s.transition(prID, transitionClose, change, func(pr *domain.PullRequest) error {
+		now := time.Now()
+		pr.ClosedAt = &now
+		pr.AssignedReviewers = nil
+		pr.FallbackReviewers = nil
+		pr.Selections = nil
+		pr.Reviews = nil
+		return nil
+	})
+}
+
+// ReopenPR moves a CLOSED PR back to OPEN and assigns fresh reviewers (idempotent operation)
+func (s *PullRequestService) ReopenPR(prID string, opts CreatePROptions) (*domain.PullRequest, error) {
+	change := domain.AssignmentChange{Actor: opts.Actor, Reason: "PR reopened"}
+	return s.transition(prID, transitionReopen, change, func(pr *domain.PullRequest) error {
+		author, err := s.getAuthor(pr)
+		if err != nil {
+			return err
+		}
+		pr.ClosedAt = nil
+		return s.assignInitialReviewers(pr, author, opts)
+	})
+}
+
+// transition loads the PR, checks the transition is allowed, lets apply update the PR and stores it
+// with the new status, recording reviewer changes with the change. A PR already in the target status
+// is returned unchanged
+func (s *PullRequestService) transition(
+	prID string,
+	t prTransition,
+	change domain.AssignmentChange,
+	apply func(pr *domain.PullRequest) error,
+) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	done, err := t.check(pr.Status)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		return pr, nil
+	}
+
+	if err := apply(pr); err != nil {
+		return nil, err
+	}
+	pr.Status = t.to
+
+	if err := s.prRepo.UpdatePR(pr, change); err != nil {
+		return nil, fmt.Errorf("failed to update PR: %w", err)
+	}
+	return pr, nil
+}
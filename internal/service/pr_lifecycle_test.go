@@ -0,0 +1,95 @@
+package service
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPRTransition_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		transition prTransition
+		status     domain.PRStatus
+		done       bool
+		err        error
+	}{
+		{"ready draft", transitionReady, domain.PRStatusDraft, false, nil},
+		{"ready open is no-op", transitionReady, domain.PRStatusOpen, true, nil},
+		{"ready closed", transitionReady, domain.PRStatusClosed, false, ErrInvalidTransition},
+		{"close draft", transitionClose, domain.PRStatusDraft, false, nil},
+		{"close open", transitionClose, domain.PRStatusOpen, false, nil},
+		{"close merged", transitionClose, domain.PRStatusMerged, false, ErrPRMerged},
+		{"reopen closed", transitionReopen, domain.PRStatusClosed, false, nil},
+		{"reopen merged", transitionReopen, domain.PRStatusMerged, false, ErrPRMerged},
+		{"merge draft", transitionMerge, domain.PRStatusDraft, false, ErrInvalidTransition},
+		{"merge closed", transitionMerge, domain.PRStatusClosed, false, ErrInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, err := tt.transition.check(tt.status)
+			assert.Equal(t, tt.done, done)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPullRequestService_DraftLifecycle(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(newPRTransactor(mockPRRepo, mockUserRepo, mockTeamRepo), mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return([]*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    1,
+		MaxReviewerCount: 1,
+	}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+	mockPRRepo.On("UpdatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	// Draft gets no reviewers
+	pr := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: domain.PRStatusDraft}
+	assert.NoError(t, service.CreatePR(pr, CreatePROptions{}))
+	assert.Equal(t, domain.PRStatusDraft, pr.Status)
+	assert.Empty(t, pr.AssignedReviewers)
+
+	// Marking ready assigns reviewers
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	result, err := service.MarkReady("pr-1", CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PRStatusOpen, result.Status)
+	assert.Equal(t, []string{"u2"}, result.AssignedReviewers)
+
+	// Closing releases reviewers
+	result, err = service.ClosePR("pr-1", domain.AssignmentChange{})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PRStatusClosed, result.Status)
+	assert.Empty(t, result.AssignedReviewers)
+	assert.NotNil(t, result.ClosedAt)
+
+	// Closed PR cannot be merged
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(pr, nil).Once()
+	_, err = service.MergePR("pr-1", true, "admin")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	// Reopening assigns fresh reviewers
+	result, err = service.ReopenPR("pr-1", CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.PRStatusOpen, result.Status)
+	assert.Equal(t, []string{"u2"}, result.AssignedReviewers)
+	assert.Nil(t, result.ClosedAt)
+
+	mockPRRepo.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+const (
+	// DefaultPRListLimit is the page size of a PR listing when none is requested
+	DefaultPRListLimit = 50
+	// MaxPRListLimit caps the page size of a PR listing
+	MaxPRListLimit = 200
+)
+
+var (
+	ErrInvalidStatus = errors.New("unknown PR status")
+	ErrInvalidCursor = errors.New("malformed page cursor")
+	ErrInvalidLimit  = errors.New("page limit is out of range")
+)
+
+// ListPRs returns a page of PRs matching the query, ordered by creation time. The cursor is the
+// NextCursor of the previous page (empty = first page); limit 0 uses DefaultPRListLimit
+func (s *PullRequestService) ListPRs(query domain.PRQuery, cursor string, limit int) (*domain.PRPage, error) {
+	if query.Status != "" && !query.Status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
+	if query.Priority != "" && !query.Priority.IsValid() {
+		return nil, ErrInvalidPriority
+	}
+	query.Label = strings.ToLower(strings.TrimSpace(query.Label))
+
+	if limit == 0 {
+		limit = DefaultPRListLimit
+	}
+	if limit < 0 || limit > MaxPRListLimit {
+		return nil, ErrInvalidLimit
+	}
+
+	var after *repository.PRCursor
+	if cursor != "" {
+		decoded, err := decodePRCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = decoded
+	}
+
+	// One extra PR tells whether there is a next page
+	prs, err := s.prRepo.ListPRs(query, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PRs: %w", err)
+	}
+
+	page := &domain.PRPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		last := page.PullRequests[limit-1]
+		if last.CreatedAt == nil {
+			return nil, fmt.Errorf("PR %s has no creation time", last.PullRequestID)
+		}
+		page.NextCursor = encodePRCursor(repository.PRCursor{
+			CreatedAt:     *last.CreatedAt,
+			PullRequestID: last.PullRequestID,
+		})
+	}
+
+	return page, nil
+}
+
+// encodePRCursor packs the keyset position into an opaque URL-safe string
+func encodePRCursor(cursor repository.PRCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + "|" + cursor.PullRequestID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePRCursor unpacks a cursor made by encodePRCursor
+func decodePRCursor(cursor string) (*repository.PRCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	createdAt, prID, found := strings.Cut(string(raw), "|")
+	if !found || prID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	parsed, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &repository.PRCursor{CreatedAt: parsed, PullRequestID: prID}, nil
+}
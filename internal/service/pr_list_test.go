@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPRCursor_RoundTrip(t *testing.T) {
+	cursor := repository.PRCursor{
+		CreatedAt:     time.Date(2025, 11, 30, 12, 0, 0, 123456000, time.UTC),
+		PullRequestID: "pr|1",
+	}
+
+	decoded, err := decodePRCursor(encodePRCursor(cursor))
+	assert.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.PullRequestID, decoded.PullRequestID)
+
+	_, err = decodePRCursor("not a cursor")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestPullRequestService_ListPRs_Pagination(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	created := time.Date(2025, 11, 30, 12, 0, 0, 0, time.UTC)
+	prs := make([]*domain.PullRequestShort, 3)
+	for i := range prs {
+		createdAt := created.Add(-time.Duration(i) * time.Hour)
+		prs[i] = &domain.PullRequestShort{PullRequestID: []string{"pr-3", "pr-2", "pr-1"}[i], CreatedAt: &createdAt}
+	}
+
+	query := domain.PRQuery{Status: domain.PRStatusOpen, Label: "bug"}
+	mockPRRepo.On("ListPRs", query, (*repository.PRCursor)(nil), 3).Return(prs, nil)
+
+	page, err := service.ListPRs(domain.PRQuery{Status: domain.PRStatusOpen, Label: " Bug "}, "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, page.PullRequests, 2)
+	assert.NotEmpty(t, page.NextCursor)
+
+	after, err := decodePRCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, "pr-2", after.PullRequestID)
+
+	mockPRRepo.On("ListPRs", query, after, 3).Return(prs[2:], nil)
+
+	page, err = service.ListPRs(query, page.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Len(t, page.PullRequests, 1)
+	assert.Empty(t, page.NextCursor)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_ListPRs_InvalidQuery(t *testing.T) {
+	service := NewPullRequestService(nil, new(MockPullRequestRepository), new(MockUserRepository), new(MockTeamRepository))
+
+	tests := []struct {
+		name   string
+		query  domain.PRQuery
+		cursor string
+		limit  int
+		err    error
+	}{
+		{"unknown status", domain.PRQuery{Status: "PENDING"}, "", 0, ErrInvalidStatus},
+		{"unknown priority", domain.PRQuery{Priority: "asap"}, "", 0, ErrInvalidPriority},
+		{"limit too large", domain.PRQuery{}, "", MaxPRListLimit + 1, ErrInvalidLimit},
+		{"negative limit", domain.PRQuery{}, "", -1, ErrInvalidLimit},
+		{"malformed cursor", domain.PRQuery{}, "%%%", 0, ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ListPRs(tt.query, tt.cursor, tt.limit)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
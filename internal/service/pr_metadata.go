@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+var (
+	ErrInvalidPriority = errors.New("unknown PR priority")
+	ErrInvalidSize     = errors.New("PR size must not be negative")
+	ErrInvalidLabel    = errors.New("labels must be non-empty and at most 64 characters")
+	ErrInvalidPRName   = errors.New("PR name must not be empty")
+)
+
+// PRMetadataUpdate holds the PR fields to change; nil fields are left as is
+type PRMetadataUpdate struct {
+	PullRequestName *string
+	Description     *string
+	// Labels replace the current labels; an empty list removes them all
+	Labels   *[]string
+	Priority *domain.PRPriority
+	Size     *int
+	// DependsOn replaces the PRs this one depends on; an empty list removes them all
+	DependsOn *[]string
+}
+
+// PRListFilter narrows lists of PRs; zero fields match any PR
+type PRListFilter struct {
+	// AwaitingVerdict keeps only PRs the reviewer has not submitted a verdict on
+	AwaitingVerdict bool
+	// IncludeArchived keeps archived PRs, which are skipped by default
+	IncludeArchived bool
+	Priority        domain.PRPriority
+	Label           string
+}
+
+// Validate normalizes the label and checks the priority of the filter
+func (f *PRListFilter) Validate() error {
+	if f.Priority != "" && !f.Priority.IsValid() {
+		return ErrInvalidPriority
+	}
+	f.Label = strings.ToLower(strings.TrimSpace(f.Label))
+	return nil
+}
+
+// matches reports whether the PR passes the priority and label filters
+func (f PRListFilter) matches(pr *domain.PullRequestShort) bool {
+	if f.Priority != "" && pr.Priority != f.Priority {
+		return false
+	}
+	if f.Label == "" {
+		return true
+	}
+	for _, label := range pr.Labels {
+		if label == f.Label {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMetadata validates the metadata of a PR, deduplicates its labels
+// and defaults the priority to normal
+func normalizeMetadata(pr *domain.PullRequest) error {
+	if pr.Priority == "" {
+		pr.Priority = domain.PRPriorityNormal
+	}
+	if !pr.Priority.IsValid() {
+		return ErrInvalidPriority
+	}
+	if pr.Size < 0 {
+		return ErrInvalidSize
+	}
+
+	labels, err := normalizeTags(pr.Labels)
+	if err != nil {
+		return ErrInvalidLabel
+	}
+	pr.Labels = labels
+	return nil
+}
+
+// UpdatePRMetadata changes name, description, labels, priority, size and dependencies of a PR that is
+// not merged. Reviewers already assigned are kept; priority, size and dependencies only affect later assignments
+func (s *PullRequestService) UpdatePRMetadata(prID string, update PRMetadataUpdate) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.GetPR(prID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, ErrPRMerged
+	}
+
+	if update.PullRequestName != nil {
+		if strings.TrimSpace(*update.PullRequestName) == "" {
+			return nil, ErrInvalidPRName
+		}
+		pr.PullRequestName = *update.PullRequestName
+	}
+	if update.Description != nil {
+		pr.Description = *update.Description
+	}
+	if update.Labels != nil {
+		pr.Labels = *update.Labels
+	}
+	if update.Priority != nil {
+		pr.Priority = *update.Priority
+		if pr.Priority == "" {
+			return nil, ErrInvalidPriority
+		}
+	}
+	if update.Size != nil {
+		pr.Size = *update.Size
+	}
+
+	if err := normalizeMetadata(pr); err != nil {
+		return nil, err
+	}
+
+	if update.DependsOn != nil {
+		pr.DependsOn = normalizeDependencies(*update.DependsOn)
+		if err := s.checkDependencies(pr.PullRequestID, pr.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.prRepo.UpdatePRMetadata(pr); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to update PR metadata: %w", err)
+	}
+
+	return pr, nil
+}
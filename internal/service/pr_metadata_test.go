@@ -0,0 +1,158 @@
+package service
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNormalizeMetadata(t *testing.T) {
+	pr := &domain.PullRequest{Labels: []string{" Bug ", "bug", "api"}}
+	assert.NoError(t, normalizeMetadata(pr))
+	assert.Equal(t, domain.PRPriorityNormal, pr.Priority)
+	assert.Equal(t, []string{"bug", "api"}, pr.Labels)
+
+	assert.ErrorIs(t, normalizeMetadata(&domain.PullRequest{Priority: "asap"}), ErrInvalidPriority)
+	assert.ErrorIs(t, normalizeMetadata(&domain.PullRequest{Size: -1}), ErrInvalidSize)
+	assert.ErrorIs(t, normalizeMetadata(&domain.PullRequest{Labels: []string{" "}}), ErrInvalidLabel)
+}
+
+func TestPullRequestService_CreatePR_UrgentGoesToLeastLoaded(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    1,
+		MaxReviewerCount: 1,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return([]*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{"u2": 4, "u3": 0, "u4": 2}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Priority: domain.PRPriorityUrgent}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+	assert.Equal(t, domain.ReviewerStrategyLeastLoaded, pr.Selections[0].Strategy)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_LargePRGetsExtraReviewer(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		count int
+	}{
+		{"small PR", 100, 1},
+		{"large PR", 500, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPRRepo := new(MockPullRequestRepository)
+			mockUserRepo := new(MockUserRepository)
+			mockTeamRepo := new(MockTeamRepository)
+
+			service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+			mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+			mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+			mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+				TeamName:         "backend",
+				ReviewerStrategy: domain.ReviewerStrategyRandom,
+				ReviewerCount:    1,
+				MaxReviewerCount: 3,
+				LargePRLines:     500,
+			}, nil)
+			mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return([]*domain.User{
+				{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+				{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+				{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+			}, nil)
+			mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+			pr := &domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Size: tt.size}
+
+			err := service.CreatePR(pr, CreatePROptions{})
+			assert.NoError(t, err)
+			assert.Len(t, pr.AssignedReviewers, tt.count)
+		})
+	}
+}
+
+func TestPullRequestService_UpdatePRMetadata(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		PullRequestName:   "Old name",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		Priority:          domain.PRPriorityNormal,
+		Labels:            []string{"bug"},
+		AssignedReviewers: []string{"u2"},
+	}
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	mockPRRepo.On("UpdatePRMetadata", pr).Return(nil)
+
+	priority := domain.PRPriorityUrgent
+	labels := []string{"API", "hotfix"}
+	result, err := service.UpdatePRMetadata("pr-1", PRMetadataUpdate{Priority: &priority, Labels: &labels})
+	assert.NoError(t, err)
+	assert.Equal(t, "Old name", result.PullRequestName)
+	assert.Equal(t, domain.PRPriorityUrgent, result.Priority)
+	assert.Equal(t, []string{"api", "hotfix"}, result.Labels)
+	assert.Equal(t, []string{"u2"}, result.AssignedReviewers)
+
+	size := -5
+	_, err = service.UpdatePRMetadata("pr-1", PRMetadataUpdate{Size: &size})
+	assert.ErrorIs(t, err, ErrInvalidSize)
+
+	mockPRRepo.AssertNumberOfCalls(t, "UpdatePRMetadata", 1)
+}
+
+func TestPullRequestService_GetPRsByReviewer_Filter(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend"}, nil)
+	mockPRRepo.On("GetPRsByReviewer", "u2", false).Return([]*domain.PullRequestShort{
+		{PullRequestID: "pr-1", Priority: domain.PRPriorityUrgent, Labels: []string{"bug"}},
+		{PullRequestID: "pr-2", Priority: domain.PRPriorityUrgent},
+		{PullRequestID: "pr-3", Priority: domain.PRPriorityLow, Labels: []string{"bug"}},
+	}, nil)
+
+	filter := PRListFilter{Priority: domain.PRPriorityUrgent, Label: " BUG"}
+	assert.NoError(t, filter.Validate())
+
+	prs, err := service.GetPRsByReviewer("u2", filter)
+	assert.NoError(t, err)
+	assert.Len(t, prs, 1)
+	assert.Equal(t, "pr-1", prs[0].PullRequestID)
+
+	invalid := PRListFilter{Priority: "asap"}
+	assert.ErrorIs(t, invalid.Validate(), ErrInvalidPriority)
+}
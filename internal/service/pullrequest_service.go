@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
+	"sync"
+	"time"
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
@@ -16,28 +19,109 @@ var (
 	ErrNotAssigned    = errors.New("reviewer is not assigned")
 	ErrNoCandidate    = errors.New("no active replacement candidate")
 	ErrAuthorNotFound = errors.New("author not found")
+	// ErrCapacityExhausted is returned when every candidate is at capacity and the team rejects overflow
+	ErrCapacityExhausted = errors.New("all candidate reviewers are at capacity")
+	// ErrReviewerCountOutOfRange is returned when a PR requests a reviewer count outside team bounds
+	ErrReviewerCountOutOfRange = errors.New("reviewer count is out of team bounds")
+	// ErrReviewerLimitReached is returned when a reviewer is added to a PR that already has max_reviewer_count reviewers
+	ErrReviewerLimitReached = errors.New("reviewer limit reached")
+	ErrInvalidVerdict       = errors.New("unknown review verdict")
+	// ErrNotApproved is returned when a PR lacks the team's required approvals or has changes requested
+	ErrNotApproved = errors.New("PR is not approved")
+	// ErrPRNotOpen is returned when reviewers or reviews of a DRAFT or CLOSED PR are changed
+	ErrPRNotOpen           = errors.New("PR is not open")
+	ErrSelfReview          = errors.New("author cannot review own PR")
+	ErrReviewerUnavailable = errors.New("reviewer is inactive or absent")
+	ErrReviewerOutsideTeam = errors.New("reviewer is not in the author's team or its fallback teams")
+	ErrDeclineReason       = errors.New("decline reason is required")
 )
 
+// CreatePROptions holds per-request parameters of reviewer assignment that are not stored on the PR;
+// they are passed on creation or, for a draft, when it is marked ready
+type CreatePROptions struct {
+	// ReviewerCount overrides the team's reviewer count within the team's bounds
+	ReviewerCount *int
+	// ChangedFiles are paths touched by the PR; their code owners are assigned first
+	ChangedFiles []string
+	// Actor is who asked for the assignment; it is recorded in the assignment history
+	Actor string
+}
+
+// SystemActor is the actor of assignment changes made by background jobs
+const SystemActor = "system"
+
 type PullRequestService struct {
-	prRepo   repository.PullRequestRepository
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
+	// transactor runs the changes that must see a consistent PR, such as a merge and its checks
+	transactor repository.Transactor
+	prRepo     repository.PullRequestRepository
+	userRepo   repository.UserRepository
+	teamRepo   repository.TeamRepository
+	selectors  map[domain.ReviewerStrategy]ReviewerSelector
+
+	// seeds generates the seed of every assignment
+	seedMu *sync.Mutex
+	seeds  *rand.Rand
 }
 
 func NewPullRequestService(
+	transactor repository.Transactor,
 	prRepo repository.PullRequestRepository,
 	userRepo repository.UserRepository,
 	teamRepo repository.TeamRepository,
 ) *PullRequestService {
 	return &PullRequestService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		transactor: transactor,
+		prRepo:     prRepo,
+		userRepo:   userRepo,
+		teamRepo:   teamRepo,
+		selectors:  NewReviewerSelectors(),
+		seedMu:     &sync.Mutex{},
+		seeds:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// withRepositories returns a service working on the given repositories, such as ones bound to
+// a transaction; it shares the selectors and the seed source with s
+func (s *PullRequestService) withRepositories(repos repository.Repositories) *PullRequestService {
+	return &PullRequestService{
+		transactor: s.transactor,
+		prRepo:     repos.PullRequests,
+		userRepo:   repos.Users,
+		teamRepo:   repos.Teams,
+		selectors:  s.selectors,
+		seedMu:     s.seedMu,
+		seeds:      s.seeds,
+	}
+}
+
+// forPlanning returns a service working on the given repositories to compute changes that are not
+// applied: it has its own copy of the selector state and its own seed source, so planning moves neither
+// the round-robin cursor nor the sequence of assignment seeds of s
+func (s *PullRequestService) forPlanning(repos repository.Repositories) *PullRequestService {
+	selectors := make(map[domain.ReviewerStrategy]ReviewerSelector, len(s.selectors))
+	for strategy, selector := range s.selectors {
+		if roundRobin, ok := selector.(*RoundRobinSelector); ok {
+			selector = roundRobin.clone()
+		}
+		selectors[strategy] = selector
+	}
+
+	return &PullRequestService{
+		transactor: s.transactor,
+		prRepo:     repos.PullRequests,
+		userRepo:   repos.Users,
+		teamRepo:   repos.Teams,
+		selectors:  selectors,
+		seedMu:     &sync.Mutex{},
+		seeds:      rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
-// CreatePR creates a new PR and automatically assigns up to 2 active reviewers from author's team
-func (s *PullRequestService) CreatePR(pr *domain.PullRequest) error {
+// CreatePR creates a new PR and automatically assigns active reviewers. Code owners of the changed
+// files are assigned first, remaining slots are filled from author's team using the team's
+// reviewer count, selection strategy and capacity limits, preferring members covering the PR's required tags.
+// A PR created as DRAFT gets no reviewers until it is marked ready
+func (s *PullRequestService) CreatePR(pr *domain.PullRequest, opts CreatePROptions) error {
 	exists, err := s.prRepo.PRExists(pr.PullRequestID)
 	if err != nil {
 		return fmt.Errorf("failed to check PR existence: %w", err)
@@ -46,100 +130,203 @@ func (s *PullRequestService) CreatePR(pr *domain.PullRequest) error {
 		return ErrPRExists
 	}
 
-	author, err := s.userRepo.GetUser(pr.AuthorID)
+	author, err := s.getAuthor(pr)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return ErrAuthorNotFound
-		}
-		return fmt.Errorf("failed to get author: %w", err)
+		return err
 	}
 
-	candidates, err := s.userRepo.GetActiveUsersByTeam(author.TeamName, []string{pr.AuthorID})
+	pr.RequiredTags, err = normalizeTags(pr.RequiredTags)
 	if err != nil {
-		return fmt.Errorf("failed to get active users: %w", err)
+		return err
 	}
 
-	pr.AssignedReviewers = s.selectReviewers(candidates, 2)
+	if err := normalizeMetadata(pr); err != nil {
+		return err
+	}
 
-	pr.Status = domain.PRStatusOpen
+	pr.DependsOn = normalizeDependencies(pr.DependsOn)
+	if err := s.checkDependencies(pr.PullRequestID, pr.DependsOn); err != nil {
+		return err
+	}
+
+	if pr.Status != domain.PRStatusDraft {
+		pr.Status = domain.PRStatusOpen
+		if err := s.assignInitialReviewers(pr, author, opts); err != nil {
+			return err
+		}
+	}
 
-	if err := s.prRepo.CreatePR(pr); err != nil {
+	change := domain.AssignmentChange{Actor: opts.Actor, Reason: "PR created"}
+	if err := s.prRepo.CreatePR(pr, change); err != nil {
 		return fmt.Errorf("failed to create PR: %w", err)
 	}
 
 	return nil
 }
 
-// MergePR marks a PR as merged (idempotent operation)
-func (s *PullRequestService) MergePR(prID string) (*domain.PullRequest, error) {
-	pr, err := s.prRepo.MergePR(prID)
+// getAuthor returns the author of the PR
+func (s *PullRequestService) getAuthor(pr *domain.PullRequest) (*domain.User, error) {
+	author, err := s.userRepo.GetUser(pr.AuthorID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return nil, ErrPRNotFound
+			return nil, ErrAuthorNotFound
 		}
-		return nil, fmt.Errorf("failed to merge PR: %w", err)
+		return nil, fmt.Errorf("failed to get author: %w", err)
 	}
-	return pr, nil
+	return author, nil
 }
 
-// ReassignReviewer replaces one reviewer with another random active user from the replaced reviewer's team
-func (s *PullRequestService) ReassignReviewer(prID string, oldUserID string) (*domain.PullRequest, string, error) {
-	// Get PR
-	pr, err := s.prRepo.GetPR(prID)
+// assignInitialReviewers fills reviewers of a PR that has none: code owners of the changed files
+// first, then reviewers of the PRs it depends on, then members of the author's team and its fallback teams
+func (s *PullRequestService) assignInitialReviewers(pr *domain.PullRequest, author *domain.User, opts CreatePROptions) error {
+	settings, err := s.teamRepo.GetTeamSettings(author.TeamName)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return nil, "", ErrPRNotFound
-		}
-		return nil, "", fmt.Errorf("failed to get PR: %w", err)
+		return fmt.Errorf("failed to get team settings: %w", err)
 	}
 
-	if pr.Status == domain.PRStatusMerged {
-		return nil, "", ErrPRMerged
+	count := settings.ReviewerCount
+	if opts.ReviewerCount != nil {
+		count = *opts.ReviewerCount
+		if count < settings.MinReviewerCount || count > settings.MaxReviewerCount {
+			return ErrReviewerCountOutOfRange
+		}
+	} else if settings.LargePRLines > 0 && pr.Size >= settings.LargePRLines && count < settings.MaxReviewerCount {
+		count++
 	}
 
-	found := false
-	for _, reviewerID := range pr.AssignedReviewers {
-		if reviewerID == oldUserID {
-			found = true
-			break
-		}
+	req := s.newAssignmentRequest()
+	owners, err := s.pickCodeOwners(settings, pr.AuthorID, opts.ChangedFiles, count, req.Rand)
+	if err != nil {
+		return err
 	}
-	if !found {
-		return nil, "", ErrNotAssigned
+
+	picked := newReviewerAssignment(req.Seed)
+	picked.add(owners, settings.ReviewerStrategy, false)
+
+	stack, err := s.pickStackReviewers(pr, count-len(picked.Reviewers), append([]string{pr.AuthorID}, picked.Reviewers...))
+	if err != nil {
+		return err
 	}
+	picked.add(stack, settings.ReviewerStrategy, false)
+
+	req.AuthorID = pr.AuthorID
+	req.ExcludeIDs = append([]string{pr.AuthorID}, picked.Reviewers...)
+	req.Count = count - len(picked.Reviewers)
+	req.RequiredTags = picked.uncoveredTags(pr.RequiredTags)
+	req.Strategy = strategyForPriority(pr.Priority)
 
-	oldReviewer, err := s.userRepo.GetUser(oldUserID)
+	assignment, err := s.assignReviewers(settings, req)
 	if err != nil {
-		if errors.Is(err, repository.ErrNotFound) {
-			return nil, "", ErrUserNotFound
+		return err
+	}
+	pr.AssignedReviewers = append(picked.Reviewers, assignment.Reviewers...)
+	pr.FallbackReviewers = assignment.Fallback
+	pr.Selections = append(picked.Selections, assignment.Selections...)
+	return nil
+}
+
+// MergePR marks an OPEN PR as merged (idempotent operation). Every PR it depends on must be merged first.
+// Unless force is set, the PR must have the required approvals of the author's team and no reviewer
+// requesting changes; a forced merge is recorded in the PR history with the actor. The checks and the merge
+// run in one transaction holding the PR locked, so a verdict or reviewer change made meanwhile is either
+// seen by the checks or waits for the merge
+func (s *PullRequestService) MergePR(prID string, force bool, actor string) (*domain.PullRequest, error) {
+	var merged *domain.PullRequest
+	err := s.transactor.WithinTx(func(repos repository.Repositories) error {
+		tx := s.withRepositories(repos)
+
+		pr, err := tx.prRepo.GetPRForUpdate(prID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return ErrPRNotFound
+			}
+			return fmt.Errorf("failed to get PR: %w", err)
+		}
+
+		done, err := transitionMerge.check(pr.Status)
+		if err != nil {
+			return err
+		}
+		if done {
+			merged = pr
+			return nil
 		}
-		return nil, "", fmt.Errorf("failed to get old reviewer: %w", err)
+
+		if err := tx.checkDependenciesMerged(pr); err != nil {
+			return err
+		}
+
+		if !force {
+			if err := tx.checkApprovals(pr); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.prRepo.MergePR(prID, force, actor); err != nil {
+			return fmt.Errorf("failed to merge PR: %w", err)
+		}
+
+		merged, err = tx.prRepo.GetPR(prID)
+		if err != nil {
+			return fmt.Errorf("failed to get merged PR: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return merged, nil
+}
 
-	excludeIDs := []string{oldUserID}
-	for _, reviewerID := range pr.AssignedReviewers {
-		if reviewerID != oldUserID {
-			excludeIDs = append(excludeIDs, reviewerID)
+// checkApprovals returns ErrNotApproved when an assigned reviewer requested changes or the PR has
+// fewer approvals than the author's team requires
+func (s *PullRequestService) checkApprovals(pr *domain.PullRequest) error {
+	approvals := 0
+	for _, review := range pr.Reviews {
+		switch review.Verdict {
+		case domain.ReviewVerdictChangesRequested:
+			return ErrNotApproved
+		case domain.ReviewVerdictApproved:
+			approvals++
 		}
 	}
 
-	candidates, err := s.userRepo.GetActiveUsersByTeam(oldReviewer.TeamName, excludeIDs)
+	author, err := s.userRepo.GetUser(pr.AuthorID)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get active users: %w", err)
+		return fmt.Errorf("failed to get author: %w", err)
 	}
 
-	if len(candidates) == 0 {
-		return nil, "", ErrNoCandidate
+	settings, err := s.teamRepo.GetTeamSettings(author.TeamName)
+	if err != nil {
+		return fmt.Errorf("failed to get team settings: %w", err)
 	}
 
-	newReviewer := s.selectReviewers(candidates, 1)
-	if len(newReviewer) == 0 {
-		return nil, "", ErrNoCandidate
+	if approvals < settings.RequiredApprovals {
+		return ErrNotApproved
+	}
+	return nil
+}
+
+// ReassignReviewer replaces one reviewer with another active user from the replaced reviewer's team
+// picked by that team's reviewer selection strategy, falling back to the team's fallback teams
+func (s *PullRequestService) ReassignReviewer(
+	prID string,
+	oldUserID string,
+	change domain.AssignmentChange,
+) (*domain.PullRequest, string, error) {
+	pr, err := s.getOpenPR(prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	oldReviewer, err := s.getAssignedReviewer(pr, oldUserID)
+	if err != nil {
+		return nil, "", err
 	}
-	newUserID := newReviewer[0]
 
-	if reassignErr := s.prRepo.ReassignReviewer(prID, oldUserID, newUserID); reassignErr != nil {
-		return nil, "", fmt.Errorf("failed to reassign reviewer: %w", reassignErr)
+	newUserID, err := s.replaceReviewer(pr, oldReviewer, nil, change)
+	if err != nil {
+		return nil, "", err
 	}
 
 	updatedPR, err := s.prRepo.GetPR(prID)
@@ -150,47 +337,266 @@ func (s *PullRequestService) ReassignReviewer(prID string, oldUserID string) (*d
 	return updatedPR, newUserID, nil
 }
 
-// GetPRsByReviewer returns all PRs where the user is assigned as reviewer
-func (s *PullRequestService) GetPRsByReviewer(userID string) ([]*domain.PullRequestShort, error) {
-	_, err := s.userRepo.GetUser(userID)
+// AddReviewer assigns a specific user to review an open PR. The user must be active, present, not the
+// author and a member of the author's team, its overflow team or one of its fallback teams, and the PR must
+// stay within the team's max reviewer count (idempotent for an already assigned reviewer)
+func (s *PullRequestService) AddReviewer(
+	prID string,
+	userID string,
+	change domain.AssignmentChange,
+) (*domain.PullRequest, error) {
+	var updatedPR *domain.PullRequest
+	err := s.transactor.WithinTx(func(repos repository.Repositories) error {
+		var err error
+		updatedPR, err = s.withRepositories(repos).addReviewer(prID, userID, change)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedPR, nil
+}
+
+// addReviewer is AddReviewer on repositories bound to a transaction. The PR stays locked until the
+// transaction ends, so of concurrent adds each one counts the reviewers added by the others
+func (s *PullRequestService) addReviewer(
+	prID string,
+	userID string,
+	change domain.AssignmentChange,
+) (*domain.PullRequest, error) {
+	pr, err := s.lockOpenPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if containsString(pr.AssignedReviewers, userID) {
+		return pr, nil
+	}
+	if userID == pr.AuthorID {
+		return nil, ErrSelfReview
+	}
+
+	reviewer, err := s.userRepo.GetUser(userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, ErrUserNotFound
 		}
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		return nil, fmt.Errorf("failed to get reviewer: %w", err)
+	}
+	if !reviewer.IsActive || reviewer.IsAbsent {
+		return nil, ErrReviewerUnavailable
 	}
 
-	prs, err := s.prRepo.GetPRsByReviewer(userID)
+	author, err := s.getAuthor(pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
+		return nil, err
+	}
+	settings, err := s.teamRepo.GetTeamSettings(author.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
 	}
 
-	return prs, nil
+	isFallback := reviewer.TeamName != settings.TeamName
+	if isFallback && reviewer.TeamName != settings.OverflowTeam &&
+		!containsString(settings.FallbackTeams, reviewer.TeamName) {
+		return nil, ErrReviewerOutsideTeam
+	}
+
+	if len(pr.AssignedReviewers) >= settings.MaxReviewerCount {
+		return nil, ErrReviewerLimitReached
+	}
+
+	if err := s.prRepo.AddReviewer(prID, userID, isFallback, change); err != nil {
+		return nil, fmt.Errorf("failed to add reviewer: %w", err)
+	}
+
+	updatedPR, err := s.prRepo.GetPR(prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	return updatedPR, nil
 }
 
-// selectReviewers randomly selects up to maxCount reviewers from candidates
-func (s *PullRequestService) selectReviewers(candidates []*domain.User, maxCount int) []string {
-	if len(candidates) == 0 {
-		return []string{}
+// RemoveReviewer unassigns a reviewer from an open PR without picking a replacement
+func (s *PullRequestService) RemoveReviewer(
+	prID string,
+	userID string,
+	change domain.AssignmentChange,
+) (*domain.PullRequest, error) {
+	pr, err := s.getOpenPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(pr.AssignedReviewers, userID) {
+		return nil, ErrNotAssigned
 	}
 
-	shuffled := make([]*domain.User, len(candidates))
-	copy(shuffled, candidates)
-	rand.Shuffle(len(shuffled), func(i, j int) {
-		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-	})
+	if err := s.prRepo.RemoveReviewer(prID, userID, change); err != nil {
+		return nil, fmt.Errorf("failed to remove reviewer: %w", err)
+	}
+
+	updatedPR, err := s.prRepo.GetPR(prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+	return updatedPR, nil
+}
+
+// DeclineReview lets an assigned reviewer of an open PR step down with a reason. A replacement is picked
+// with the reviewer's team selection strategy, skipping the author and current reviewers; when nobody can
+// take the review over the reviewer is removed without one. It returns the replacement's user ID, if any
+func (s *PullRequestService) DeclineReview(prID string, userID string, reason string) (*domain.PullRequest, string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, "", ErrDeclineReason
+	}
+
+	pr, err := s.getOpenPR(prID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	reviewer, err := s.getAssignedReviewer(pr, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	exclude := append([]string{pr.AuthorID}, pr.AssignedReviewers...)
+	selection, fromFallback, err := s.pickReplacement(pr, reviewer, exclude)
+
+	var replacement *domain.ReviewerSelection
+	switch {
+	case err == nil:
+		replacement = &selection
+	case errors.Is(err, ErrNoCandidate), errors.Is(err, ErrCapacityExhausted):
+		// Nobody can take the review over, the reviewer is removed anyway
+	default:
+		return nil, "", err
+	}
+
+	if err := s.prRepo.DeclineReview(prID, userID, reason, replacement, fromFallback); err != nil {
+		return nil, "", fmt.Errorf("failed to decline review: %w", err)
+	}
 
-	count := maxCount
-	if len(shuffled) < maxCount {
-		count = len(shuffled)
+	updatedPR, err := s.prRepo.GetPR(prID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get updated PR: %w", err)
 	}
 
-	reviewers := make([]string, 0, count)
-	for i := 0; i < count; i++ {
-		reviewers = append(reviewers, shuffled[i].UserID)
+	if replacement == nil {
+		return updatedPR, "", nil
+	}
+	return updatedPR, replacement.UserID, nil
+}
+
+// getOpenPR returns the PR if its reviewers can be changed
+func (s *PullRequestService) getOpenPR(prID string) (*domain.PullRequest, error) {
+	return checkOpenPR(s.prRepo.GetPR(prID))
+}
+
+// lockOpenPR is getOpenPR keeping the PR locked until the transaction of s ends
+func (s *PullRequestService) lockOpenPR(prID string) (*domain.PullRequest, error) {
+	return checkOpenPR(s.prRepo.GetPRForUpdate(prID))
+}
+
+// checkOpenPR returns the PR read by the repository if it is OPEN
+func checkOpenPR(pr *domain.PullRequest, err error) (*domain.PullRequest, error) {
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotFound
+		}
+		return nil, fmt.Errorf("failed to get PR: %w", err)
 	}
 
-	return reviewers
+	switch pr.Status {
+	case domain.PRStatusOpen:
+		return pr, nil
+	case domain.PRStatusMerged:
+		return nil, ErrPRMerged
+	default:
+		return nil, ErrPRNotOpen
+	}
+}
+
+// getAssignedReviewer returns the user if they are assigned to review the PR
+func (s *PullRequestService) getAssignedReviewer(pr *domain.PullRequest, userID string) (*domain.User, error) {
+	if !containsString(pr.AssignedReviewers, userID) {
+		return nil, ErrNotAssigned
+	}
+
+	reviewer, err := s.userRepo.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get reviewer: %w", err)
+	}
+	return reviewer, nil
+}
+
+// SubmitReview records the verdict of an assigned reviewer on an open PR
+func (s *PullRequestService) SubmitReview(
+	prID string,
+	userID string,
+	verdict domain.ReviewVerdict,
+) (*domain.PullRequest, error) {
+	if !verdict.IsValid() {
+		return nil, ErrInvalidVerdict
+	}
+
+	pr, err := s.getOpenPR(prID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsString(pr.AssignedReviewers, userID) {
+		return nil, ErrNotAssigned
+	}
+
+	if err := s.prRepo.SubmitReview(prID, userID, verdict); err != nil {
+		// The PR was merged or closed after it was read
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPRNotOpen
+		}
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+
+	updatedPR, err := s.prRepo.GetPR(prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated PR: %w", err)
+	}
+
+	return updatedPR, nil
+}
+
+// GetPRsByReviewer returns PRs where the user is assigned as reviewer and that pass the filter
+func (s *PullRequestService) GetPRsByReviewer(userID string, filter PRListFilter) ([]*domain.PullRequestShort, error) {
+	_, err := s.userRepo.GetUser(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	var prs []*domain.PullRequestShort
+	if filter.AwaitingVerdict {
+		prs, err = s.prRepo.GetPRsAwaitingVerdict(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PRs awaiting verdict: %w", err)
+		}
+	} else {
+		prs, err = s.prRepo.GetPRsByReviewer(userID, filter.IncludeArchived)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get PRs by reviewer: %w", err)
+		}
+	}
+
+	filtered := make([]*domain.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		if filter.matches(pr) {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered, nil
 }
 
 // GetPR retrieves a PR by ID
@@ -205,9 +611,18 @@ func (s *PullRequestService) GetPR(prID string) (*domain.PullRequest, error) {
 	return pr, nil
 }
 
-// GetStats retrieves statistics about PR assignments
-func (s *PullRequestService) GetStats() (*domain.Stats, error) {
-	stats, err := s.prRepo.GetStats()
+// GetOpenPRsByReviewer returns the OPEN PRs the user is assigned to review
+func (s *PullRequestService) GetOpenPRsByReviewer(userID string) ([]*domain.PullRequest, error) {
+	prs, err := s.prRepo.GetOpenPRsByReviewers([]string{userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open PRs: %w", err)
+	}
+	return prs, nil
+}
+
+// GetStats retrieves statistics about PR assignments; archived PRs are counted only when includeArchived is set
+func (s *PullRequestService) GetStats(includeArchived bool) (*domain.Stats, error) {
+	stats, err := s.prRepo.GetStats(includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stats: %w", err)
 	}
@@ -1,9 +1,12 @@
 package service
 
 import (
+	"math/rand"
 	"testing"
+	"time"
 
 	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,8 +17,8 @@ type MockPullRequestRepository struct {
 	mock.Mock
 }
 
-func (m *MockPullRequestRepository) CreatePR(pr *domain.PullRequest) error {
-	args := m.Called(pr)
+func (m *MockPullRequestRepository) CreatePR(pr *domain.PullRequest, change domain.AssignmentChange) error {
+	args := m.Called(pr, change)
 	return args.Error(0)
 }
 
@@ -27,12 +30,17 @@ func (m *MockPullRequestRepository) GetPR(prID string) (*domain.PullRequest, err
 	return args.Get(0).(*domain.PullRequest), args.Error(1)
 }
 
-func (m *MockPullRequestRepository) UpdatePR(pr *domain.PullRequest) error {
+func (m *MockPullRequestRepository) UpdatePR(pr *domain.PullRequest, change domain.AssignmentChange) error {
+	args := m.Called(pr, change)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) UpdatePRMetadata(pr *domain.PullRequest) error {
 	args := m.Called(pr)
 	return args.Error(0)
 }
 
-func (m *MockPullRequestRepository) MergePR(prID string) (*domain.PullRequest, error) {
+func (m *MockPullRequestRepository) GetPRForUpdate(prID string) (*domain.PullRequest, error) {
 	args := m.Called(prID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -40,26 +48,125 @@ func (m *MockPullRequestRepository) MergePR(prID string) (*domain.PullRequest, e
 	return args.Get(0).(*domain.PullRequest), args.Error(1)
 }
 
+func (m *MockPullRequestRepository) MergePR(prID string, force bool, actor string) error {
+	args := m.Called(prID, force, actor)
+	return args.Error(0)
+}
+
 func (m *MockPullRequestRepository) PRExists(prID string) (bool, error) {
 	args := m.Called(prID)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockPullRequestRepository) GetPRsByReviewer(userID string) ([]*domain.PullRequestShort, error) {
-	args := m.Called(userID)
+func (m *MockPullRequestRepository) GetDependencyClosure(prIDs []string) ([]string, error) {
+	args := m.Called(prIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetUnmergedDependencies(prID string) ([]string, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetDependents(prID string) ([]string, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) DeletePR(prID string) error {
+	args := m.Called(prID)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) ArchiveMergedPRs(mergedBefore time.Time) (int, error) {
+	args := m.Called(mergedBefore)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetPRsByReviewer(userID string, includeArchived bool) ([]*domain.PullRequestShort, error) {
+	args := m.Called(userID, includeArchived)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*domain.PullRequestShort), args.Error(1)
 }
 
-func (m *MockPullRequestRepository) ReassignReviewer(prID string, oldUserID string, newUserID string) error {
-	args := m.Called(prID, oldUserID, newUserID)
+func (m *MockPullRequestRepository) ReassignReviewer(
+	prID string,
+	oldUserID string,
+	replacement domain.ReviewerSelection,
+	isFallback bool,
+	change domain.AssignmentChange,
+) error {
+	args := m.Called(prID, oldUserID, replacement.UserID, isFallback, change)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) AddReviewer(
+	prID string,
+	userID string,
+	isFallback bool,
+	change domain.AssignmentChange,
+) error {
+	args := m.Called(prID, userID, isFallback, change)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) RemoveReviewer(prID string, userID string, change domain.AssignmentChange) error {
+	args := m.Called(prID, userID, change)
+	return args.Error(0)
+}
+
+func (m *MockPullRequestRepository) GetAssignmentHistory(prID string) ([]*domain.AssignmentEvent, error) {
+	args := m.Called(prID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.AssignmentEvent), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) DeclineReview(
+	prID string,
+	userID string,
+	reason string,
+	replacement *domain.ReviewerSelection,
+	isFallback bool,
+) error {
+	replacedBy := ""
+	if replacement != nil {
+		replacedBy = replacement.UserID
+	}
+	args := m.Called(prID, userID, reason, replacedBy, isFallback)
 	return args.Error(0)
 }
 
-func (m *MockPullRequestRepository) GetStats() (*domain.Stats, error) {
-	args := m.Called()
+func (m *MockPullRequestRepository) MarkOverdueAssignments(now time.Time) ([]*domain.OverdueAssignment, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OverdueAssignment), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetOverdueAssignments(teamName string) ([]*domain.OverdueAssignment, error) {
+	args := m.Called(teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.OverdueAssignment), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetStats(includeArchived bool) (*domain.Stats, error) {
+	args := m.Called(includeArchived)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -74,6 +181,47 @@ func (m *MockPullRequestRepository) GetOpenPRsByReviewers(userIDs []string) ([]*
 	return args.Get(0).([]*domain.PullRequest), args.Error(1)
 }
 
+func (m *MockPullRequestRepository) GetOpenReviewCountsByTeam(teamName string) (map[string]int, error) {
+	args := m.Called(teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetRecentPairings(authorID string, since time.Time) (map[string]int, error) {
+	args := m.Called(authorID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) GetPRsAwaitingVerdict(userID string) ([]*domain.PullRequestShort, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequestShort), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) ListPRs(
+	query domain.PRQuery,
+	after *repository.PRCursor,
+	limit int,
+) ([]*domain.PullRequestShort, error) {
+	args := m.Called(query, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.PullRequestShort), args.Error(1)
+}
+
+func (m *MockPullRequestRepository) SubmitReview(prID string, userID string, verdict domain.ReviewVerdict) error {
+	args := m.Called(prID, userID, verdict)
+	return args.Error(0)
+}
+
 // MockUserRepository is a mock implementation of UserRepository
 type MockUserRepository struct {
 	mock.Mock
@@ -113,6 +261,30 @@ func (m *MockUserRepository) BulkSetIsActive(userIDs []string, isActive bool) er
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SetMaxOpenReviews(userID string, maxOpenReviews *int) (*domain.User, error) {
+	args := m.Called(userID, maxOpenReviews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) AddUserTags(userID string, tags []string) (*domain.User, error) {
+	args := m.Called(userID, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) RemoveUserTags(userID string, tags []string) (*domain.User, error) {
+	args := m.Called(userID, tags)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
 // MockTeamRepository is a mock implementation of TeamRepository
 type MockTeamRepository struct {
 	mock.Mock
@@ -136,12 +308,33 @@ func (m *MockTeamRepository) TeamExists(teamName string) (bool, error) {
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockTeamRepository) GetTeamSettings(teamName string) (*domain.TeamSettings, error) {
+	args := m.Called(teamName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TeamSettings), args.Error(1)
+}
+
+// newPRTransactor returns a transactor running work on the mocks
+func newPRTransactor(
+	mockPRRepo *MockPullRequestRepository,
+	mockUserRepo *MockUserRepository,
+	mockTeamRepo *MockTeamRepository,
+) *fakeTransactor {
+	return &fakeTransactor{repos: repository.Repositories{
+		Teams:        mockTeamRepo,
+		Users:        mockUserRepo,
+		PullRequests: mockPRRepo,
+	}}
+}
+
 func TestPullRequestService_CreatePR(t *testing.T) {
 	mockPRRepo := new(MockPullRequestRepository)
 	mockUserRepo := new(MockUserRepository)
 	mockTeamRepo := new(MockTeamRepository)
 
-	service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
 
 	// Setup mocks
 	author := &domain.User{
@@ -159,7 +352,13 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
 	mockUserRepo.On("GetUser", "u1").Return(author, nil)
 	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
-	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest")).Return(nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
 
 	pr := &domain.PullRequest{
 		PullRequestID:   "pr-1",
@@ -167,13 +366,583 @@ func TestPullRequestService_CreatePR(t *testing.T) {
 		AuthorID:        "u1",
 	}
 
-	err := service.CreatePR(pr)
+	err := service.CreatePR(pr, CreatePROptions{})
 	assert.NoError(t, err)
 	assert.Equal(t, domain.PRStatusOpen, pr.Status)
 	assert.LessOrEqual(t, len(pr.AssignedReviewers), 2)
 
 	mockPRRepo.AssertExpectations(t)
 	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_SeededSelectionIsReproducible(t *testing.T) {
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true},
+	}
+
+	createPR := func(seed int64) *domain.PullRequest {
+		mockPRRepo := new(MockPullRequestRepository)
+		mockUserRepo := new(MockUserRepository)
+		mockTeamRepo := new(MockTeamRepository)
+
+		service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+		service.SetSeed(seed)
+
+		mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+		mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+		mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+			TeamName:         "backend",
+			ReviewerStrategy: domain.ReviewerStrategyRandom,
+			ReviewerCount:    2,
+			MaxReviewerCount: 2,
+		}, nil)
+		mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+		mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+		pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+		assert.NoError(t, service.CreatePR(pr, CreatePROptions{}))
+		return pr
+	}
+
+	first := createPR(42)
+	second := createPR(42)
+	assert.Equal(t, first.AssignedReviewers, second.AssignedReviewers)
+	assert.Equal(t, first.Selections, second.Selections)
+
+	// The recorded seed replays the pick
+	assert.Len(t, first.Selections, 2)
+	selection := first.Selections[0]
+	assert.Equal(t, domain.ReviewerStrategyRandom, selection.Strategy)
+	replayed := (&RandomSelector{}).Select(SelectionRequest{
+		TeamName:   "backend",
+		Candidates: candidates,
+		Count:      2,
+		Rand:       rand.New(rand.NewSource(selection.Seed)),
+	})
+	assert.Equal(t, first.AssignedReviewers, replayed)
+}
+
+func TestPullRequestService_CreatePR_LeastLoadedStrategy(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	}
+	openReviews := map[string]int{"u1": 3, "u2": 2, "u3": 1, "u4": 0}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyLeastLoaded,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(openReviews, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u4", "u3"}, pr.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func (m *MockTeamRepository) UpdateTeamSettings(settings *domain.TeamSettings) error {
+	args := m.Called(settings)
+	return args.Error(0)
+}
+
+func (m *MockTeamRepository) GetCodeOwners(teamName string) (string, error) {
+	args := m.Called(teamName)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTeamRepository) SetCodeOwners(teamName string, content string) error {
+	args := m.Called(teamName, content)
+	return args.Error(0)
+}
+
+func TestPullRequestService_CreatePR_AffinityDecay(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:           "backend",
+		ReviewerStrategy:   domain.ReviewerStrategyLeastLoaded,
+		ReviewerCount:      2,
+		MaxReviewerCount:   2,
+		AffinityWindowDays: 30,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{"u2": 0, "u3": 1, "u4": 1}, nil)
+	mockPRRepo.On("GetRecentPairings", "u1", mock.AnythingOfType("time.Time")).Return(map[string]int{"u2": 3}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_AssignsCodeOwnersFirst(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	dbaMembers := []*domain.User{
+		{UserID: "d1", Username: "Dana", TeamName: "dba", IsActive: true},
+	}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+	}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockTeamRepo.On("GetCodeOwners", "backend").Return("*.sql @team/dba\n/docs/ @u1\n", nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "dba", []string{"u1"}).Return(dbaMembers, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "d1"}).Return(candidates, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+	opts := CreatePROptions{ChangedFiles: []string{"migrations/002.up.sql", "docs/readme.md", "main.go"}}
+
+	err := service.CreatePR(pr, opts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"d1", "u2"}, pr.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_SkipsReviewersAtCapacity(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	limit := 1
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		CapacityOverflow: domain.CapacityOverflowAssignFewer,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{"u2": 1, "u3": 5}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, pr.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_CapacityExhausted(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	limit := 2
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:              "backend",
+		ReviewerStrategy:      domain.ReviewerStrategyRandom,
+		DefaultMaxOpenReviews: &limit,
+		CapacityOverflow:      domain.CapacityOverflowReject,
+		ReviewerCount:         2,
+		MaxReviewerCount:      2,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{"u2": 2, "u3": 3}, nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.ErrorIs(t, err, ErrCapacityExhausted)
+	mockPRRepo.AssertNotCalled(t, "CreatePR", mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_CreatePR_CapacityOverflowTeam(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	limit := 0
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+	}
+	overflowCandidates := []*domain.User{
+		{UserID: "p1", Username: "Peter", TeamName: "platform", IsActive: true},
+	}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		CapacityOverflow: domain.CapacityOverflowTeam,
+		OverflowTeam:     "platform",
+		ReviewerCount:    1,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "platform").Return(&domain.TeamSettings{
+		TeamName:         "platform",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		CapacityOverflow: domain.CapacityOverflowAssignFewer,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "platform", []string{"u1"}).Return(overflowCandidates, nil)
+	mockPRRepo.On("GetOpenReviewCountsByTeam", "backend").Return(map[string]int{}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"p1"}, pr.AssignedReviewers)
+	assert.Equal(t, []string{"p1"}, pr.FallbackReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_ReviewerCountOverride(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "security", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "security", IsActive: true},
+		{UserID: "u3", Username: "Charlie", TeamName: "security", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "security", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "security", IsActive: true},
+	}
+
+	mockPRRepo.On("PRExists", mock.Anything).Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "security").Return(&domain.TeamSettings{
+		TeamName:         "security",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    3,
+		MinReviewerCount: 2,
+		MaxReviewerCount: 4,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "security", []string{"u1"}).Return(candidates, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Default count", AuthorID: "u1"}
+	assert.NoError(t, service.CreatePR(pr, CreatePROptions{}))
+	assert.Len(t, pr.AssignedReviewers, 3)
+
+	four := 4
+	pr = &domain.PullRequest{PullRequestID: "pr-2", PullRequestName: "Override", AuthorID: "u1"}
+	assert.NoError(t, service.CreatePR(pr, CreatePROptions{ReviewerCount: &four}))
+	assert.Len(t, pr.AssignedReviewers, 4)
+
+	one := 1
+	pr = &domain.PullRequest{PullRequestID: "pr-3", PullRequestName: "Too few", AuthorID: "u1"}
+	assert.ErrorIs(t, service.CreatePR(pr, CreatePROptions{ReviewerCount: &one}), ErrReviewerCountOutOfRange)
+}
+
+func TestPullRequestService_CreatePR_CoversRequiredTags(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}
+	candidates := []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, Tags: []string{"go"}},
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+		{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true, Tags: []string{"frontend", "sql"}},
+	}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1"}).Return(candidates, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{
+		PullRequestID:   "pr-1",
+		PullRequestName: "Test PR",
+		AuthorID:        "u1",
+		RequiredTags:    []string{" SQL", "go", "sql"},
+	}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sql", "go"}, pr.RequiredTags)
+	assert.ElementsMatch(t, []string{"u2", "u5"}, pr.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_CreatePR_FillsFromFallbackTeams(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	author := &domain.User{UserID: "u1", Username: "Alice", TeamName: "mobile", IsActive: true}
+
+	mockPRRepo.On("PRExists", "pr-1").Return(false, nil)
+	mockUserRepo.On("GetUser", "u1").Return(author, nil)
+	mockTeamRepo.On("GetTeamSettings", "mobile").Return(&domain.TeamSettings{
+		TeamName:         "mobile",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewerCount:    2,
+		MaxReviewerCount: 2,
+		FallbackTeams:    []string{"frontend", "backend"},
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "frontend").Return(&domain.TeamSettings{
+		TeamName:         "frontend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "mobile", []string{"u1"}).Return([]*domain.User{}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "frontend", []string{"u1"}).Return([]*domain.User{
+		{UserID: "f1", Username: "Frank", TeamName: "frontend", IsActive: true},
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "f1"}).Return([]*domain.User{
+		{UserID: "b1", Username: "Bob", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("CreatePR", mock.AnythingOfType("*domain.PullRequest"), mock.Anything).Return(nil)
+
+	pr := &domain.PullRequest{PullRequestID: "pr-1", PullRequestName: "Test PR", AuthorID: "u1"}
+
+	err := service.CreatePR(pr, CreatePROptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f1", "b1"}, pr.AssignedReviewers)
+	assert.Equal(t, []string{"f1", "b1"}, pr.FallbackReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_ReassignReviewer_UsesFallbackTeam(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+	updatedPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"b1"},
+		FallbackReviewers: []string{"b1"},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(updatedPR, nil).Once()
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "mobile"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "mobile").Return(&domain.TeamSettings{
+		TeamName:         "mobile",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		FallbackTeams:    []string{"backend"},
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "mobile", []string{"u1", "u2"}).Return([]*domain.User{}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2"}).Return([]*domain.User{
+		{UserID: "b1", Username: "Bob", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "b1", true, mock.Anything).Return(nil)
+
+	result, newUserID, err := service.ReassignReviewer("pr-1", "u2", domain.AssignmentChange{})
+	assert.NoError(t, err)
+	assert.Equal(t, "b1", newUserID)
+	assert.Equal(t, []string{"b1"}, result.FallbackReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+	mockTeamRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_SubmitReview(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	submittedAt := time.Now()
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	reviewedPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+		Reviews: []domain.ReviewerState{
+			{UserID: "u2", Verdict: domain.ReviewVerdictApproved, SubmittedAt: submittedAt},
+		},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Times(2)
+	mockPRRepo.On("GetPR", "pr-1").Return(reviewedPR, nil).Once()
+	mockPRRepo.On("SubmitReview", "pr-1", "u2", domain.ReviewVerdictApproved).Return(nil)
+
+	_, err := service.SubmitReview("pr-1", "u2", "LGTM")
+	assert.ErrorIs(t, err, ErrInvalidVerdict)
+
+	_, err = service.SubmitReview("pr-1", "u1", domain.ReviewVerdictApproved)
+	assert.ErrorIs(t, err, ErrNotAssigned)
+
+	result, err := service.SubmitReview("pr-1", "u2", domain.ReviewVerdictApproved)
+	assert.NoError(t, err)
+	assert.Equal(t, reviewedPR.Reviews, result.Reviews)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_SubmitReview_OnlyOpenPRs(t *testing.T) {
+	tests := []struct {
+		status  domain.PRStatus
+		wantErr error
+	}{
+		{status: domain.PRStatusDraft, wantErr: ErrPRNotOpen},
+		{status: domain.PRStatusClosed, wantErr: ErrPRNotOpen},
+		{status: domain.PRStatusMerged, wantErr: ErrPRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			mockPRRepo := new(MockPullRequestRepository)
+			service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+			mockPRRepo.On("GetPR", "pr-1").Return(&domain.PullRequest{
+				PullRequestID:     "pr-1",
+				AuthorID:          "u1",
+				Status:            tt.status,
+				AssignedReviewers: []string{"u2"},
+			}, nil)
+
+			_, err := service.SubmitReview("pr-1", "u2", domain.ReviewVerdictApproved)
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockPRRepo.AssertNotCalled(t, "SubmitReview", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestPullRequestService_SubmitReview_PRMergedMeanwhile(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	service := NewPullRequestService(nil, mockPRRepo, new(MockUserRepository), new(MockTeamRepository))
+
+	mockPRRepo.On("GetPR", "pr-1").Return(&domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}, nil)
+	// The PR is merged between the read and the verdict
+	mockPRRepo.On("SubmitReview", "pr-1", "u2", domain.ReviewVerdictChangesRequested).Return(repository.ErrNotFound)
+
+	_, err := service.SubmitReview("pr-1", "u2", domain.ReviewVerdictChangesRequested)
+	assert.ErrorIs(t, err, ErrPRNotOpen)
 }
 
 func TestPullRequestService_MergePR_Idempotent(t *testing.T) {
@@ -181,24 +950,294 @@ func TestPullRequestService_MergePR_Idempotent(t *testing.T) {
 	mockUserRepo := new(MockUserRepository)
 	mockTeamRepo := new(MockTeamRepository)
 
-	service := NewPullRequestService(mockPRRepo, mockUserRepo, mockTeamRepo)
+	service := NewPullRequestService(newPRTransactor(mockPRRepo, mockUserRepo, mockTeamRepo), mockPRRepo, mockUserRepo, mockTeamRepo)
 
-	// First merge
+	openPR := &domain.PullRequest{
+		PullRequestID: "pr-1",
+		AuthorID:      "u1",
+		Status:        domain.PRStatusOpen,
+	}
 	mergedPR := &domain.PullRequest{
 		PullRequestID: "pr-1",
+		AuthorID:      "u1",
 		Status:        domain.PRStatusMerged,
 	}
 
-	mockPRRepo.On("MergePR", "pr-1").Return(mergedPR, nil)
+	// First merge
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(openPR, nil).Once()
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{TeamName: "backend"}, nil)
+	mockPRRepo.On("MergePR", "pr-1", false, "alice").Return(nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(mergedPR, nil).Once()
 
-	result1, err := service.MergePR("pr-1")
+	result1, err := service.MergePR("pr-1", false, "alice")
 	assert.NoError(t, err)
 	assert.Equal(t, domain.PRStatusMerged, result1.Status)
 
 	// Second merge (should be idempotent)
-	result2, err := service.MergePR("pr-1")
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(mergedPR, nil).Once()
+
+	result2, err := service.MergePR("pr-1", false, "alice")
 	assert.NoError(t, err)
 	assert.Equal(t, domain.PRStatusMerged, result2.Status)
 
 	mockPRRepo.AssertExpectations(t)
 }
+
+func TestPullRequestService_MergePR_ApprovalGate(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	transactor := newPRTransactor(mockPRRepo, mockUserRepo, mockTeamRepo)
+	service := NewPullRequestService(transactor, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:          "backend",
+		RequiredApprovals: 2,
+	}, nil)
+
+	newPR := func(reviews ...domain.ReviewerState) *domain.PullRequest {
+		return &domain.PullRequest{
+			PullRequestID:     "pr-1",
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2", "u3"},
+			Reviews:           reviews,
+		}
+	}
+	approved := domain.ReviewerState{UserID: "u2", Verdict: domain.ReviewVerdictApproved}
+
+	// Not enough approvals
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(newPR(approved), nil).Once()
+	_, err := service.MergePR("pr-1", false, "alice")
+	assert.ErrorIs(t, err, ErrNotApproved)
+	assert.False(t, transactor.committed)
+
+	// Changes requested block the merge
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(newPR(
+		approved,
+		domain.ReviewerState{UserID: "u3", Verdict: domain.ReviewVerdictChangesRequested},
+	), nil).Once()
+	_, err = service.MergePR("pr-1", false, "alice")
+	assert.ErrorIs(t, err, ErrNotApproved)
+
+	// Enough approvals
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(newPR(
+		approved,
+		domain.ReviewerState{UserID: "u3", Verdict: domain.ReviewVerdictApproved},
+	), nil).Once()
+	mockPRRepo.On("MergePR", "pr-1", false, "alice").Return(nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(&domain.PullRequest{
+		PullRequestID: "pr-1",
+		Status:        domain.PRStatusMerged,
+	}, nil).Once()
+	_, err = service.MergePR("pr-1", false, "alice")
+	assert.NoError(t, err)
+	assert.True(t, transactor.committed)
+
+	// Force bypasses the gate and is recorded with the actor
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(newPR(), nil).Once()
+	mockPRRepo.On("MergePR", "pr-1", true, "admin").Return(nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(&domain.PullRequest{
+		PullRequestID: "pr-1",
+		Status:        domain.PRStatusMerged,
+		ForceMerged:   true,
+	}, nil).Once()
+	result, err := service.MergePR("pr-1", true, "admin")
+	assert.NoError(t, err)
+	assert.True(t, result.ForceMerged)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_AddReviewer(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(newPRTransactor(mockPRRepo, mockUserRepo, mockTeamRepo), mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+	updatedPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"f1", "u2"},
+		FallbackReviewers: []string{"f1"},
+	}
+
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(pr, nil).Times(5)
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u3").Return(&domain.User{UserID: "u3", TeamName: "backend", IsActive: false}, nil)
+	mockUserRepo.On("GetUser", "m1").Return(&domain.User{UserID: "m1", TeamName: "mobile", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "f1").Return(&domain.User{UserID: "f1", TeamName: "frontend", IsActive: true}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		MaxReviewerCount: 2,
+		FallbackTeams:    []string{"frontend"},
+	}, nil)
+
+	_, err := service.AddReviewer("pr-1", "u1", domain.AssignmentChange{})
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	_, err = service.AddReviewer("pr-1", "u3", domain.AssignmentChange{})
+	assert.ErrorIs(t, err, ErrReviewerUnavailable)
+
+	_, err = service.AddReviewer("pr-1", "m1", domain.AssignmentChange{})
+	assert.ErrorIs(t, err, ErrReviewerOutsideTeam)
+
+	result, err := service.AddReviewer("pr-1", "u2", domain.AssignmentChange{})
+	assert.NoError(t, err)
+	assert.Same(t, pr, result)
+
+	mockPRRepo.On("AddReviewer", "pr-1", "f1", true, mock.Anything).Return(nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(updatedPR, nil).Once()
+	result, err = service.AddReviewer("pr-1", "f1", domain.AssignmentChange{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"f1"}, result.FallbackReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_AddReviewer_MaxReviewerCount(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	transactor := newPRTransactor(mockPRRepo, mockUserRepo, mockTeamRepo)
+	service := NewPullRequestService(transactor, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	// The count is checked on the PR locked for the add, so a reviewer added meanwhile is counted
+	mockPRRepo.On("GetPRForUpdate", "pr-1").Return(&domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}, nil)
+	mockUserRepo.On("GetUser", "u1").Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil)
+	mockUserRepo.On("GetUser", "u4").Return(&domain.User{UserID: "u4", TeamName: "backend", IsActive: true}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		MaxReviewerCount: 2,
+	}, nil)
+
+	_, err := service.AddReviewer("pr-1", "u4", domain.AssignmentChange{})
+	assert.ErrorIs(t, err, ErrReviewerLimitReached)
+	assert.False(t, transactor.committed)
+	mockPRRepo.AssertNotCalled(t, "GetPR", mock.Anything)
+	mockPRRepo.AssertNotCalled(t, "AddReviewer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPullRequestService_RemoveReviewer(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	updatedPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u3"},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Twice()
+	_, err := service.RemoveReviewer("pr-1", "u4", domain.AssignmentChange{})
+	assert.ErrorIs(t, err, ErrNotAssigned)
+
+	mockPRRepo.On("RemoveReviewer", "pr-1", "u2", mock.Anything).Return(nil)
+	mockPRRepo.On("GetPR", "pr-1").Return(updatedPR, nil).Once()
+	result, err := service.RemoveReviewer("pr-1", "u2", domain.AssignmentChange{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"u3"}, result.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_DeclineReview(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2", "u3"},
+	}
+	updatedPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u3", "u4"},
+	}
+
+	_, _, err := service.DeclineReview("pr-1", "u2", "  ")
+	assert.ErrorIs(t, err, ErrDeclineReason)
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil).Once()
+	mockPRRepo.On("GetPR", "pr-1").Return(updatedPR, nil).Once()
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2", "u3"}).Return([]*domain.User{
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: true},
+	}, nil)
+	mockPRRepo.On("DeclineReview", "pr-1", "u2", "on vacation", "u4", false).Return(nil)
+
+	result, replacedBy, err := service.DeclineReview("pr-1", "u2", "on vacation")
+	assert.NoError(t, err)
+	assert.Equal(t, "u4", replacedBy)
+	assert.Equal(t, []string{"u3", "u4"}, result.AssignedReviewers)
+
+	mockPRRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestPullRequestService_DeclineReview_WithoutReplacement(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	service := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+
+	pr := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		Status:            domain.PRStatusOpen,
+		AssignedReviewers: []string{"u2"},
+	}
+
+	mockPRRepo.On("GetPR", "pr-1").Return(pr, nil)
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend"}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+	}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2"}).Return([]*domain.User{}, nil)
+	mockPRRepo.On("DeclineReview", "pr-1", "u2", "conflict of interest", "", false).Return(nil)
+
+	_, replacedBy, err := service.DeclineReview("pr-1", "u2", "conflict of interest")
+	assert.NoError(t, err)
+	assert.Empty(t, replacedBy)
+
+	mockPRRepo.AssertExpectations(t)
+}
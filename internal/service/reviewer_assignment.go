@@ -0,0 +1,501 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+	"avito-tech-internship/pkg/codeowners"
+)
+
+// assignmentRequest describes the reviewers needed for a PR
+type assignmentRequest struct {
+	// AuthorID is used for affinity decay against recent reviewers of the same author
+	AuthorID   string
+	ExcludeIDs []string
+	Count      int
+	// RequiredTags should each be covered by at least one picked reviewer where possible
+	RequiredTags []string
+	// Strategy overrides the selection strategy of every team asked (empty = the team's own)
+	Strategy domain.ReviewerStrategy
+	// Seed initialized Rand; it is recorded with every pick so random and weighted picks can be replayed
+	// on the same candidates and loads (round_robin and least_loaded depend on state that is not recorded)
+	Seed int64
+	Rand *rand.Rand
+}
+
+// strategy returns the selection strategy to use for the team
+func (req assignmentRequest) strategy(settings *domain.TeamSettings) domain.ReviewerStrategy {
+	if req.Strategy != "" {
+		return req.Strategy
+	}
+	return settings.ReviewerStrategy
+}
+
+// strategyForPriority returns the strategy that overrides team strategies for PRs of the priority
+// (empty = no override): urgent PRs go to the least loaded reviewers
+func strategyForPriority(priority domain.PRPriority) domain.ReviewerStrategy {
+	if priority == domain.PRPriorityUrgent {
+		return domain.ReviewerStrategyLeastLoaded
+	}
+	return ""
+}
+
+// reviewerAssignment is the outcome of picking reviewers for a PR
+type reviewerAssignment struct {
+	Reviewers []string
+	// Fallback lists reviewers taken from overflow or fallback teams
+	Fallback   []string
+	Selections []domain.ReviewerSelection
+	// covered holds tags of the picked reviewers
+	covered map[string]bool
+	seed    int64
+}
+
+func newReviewerAssignment(seed int64) *reviewerAssignment {
+	return &reviewerAssignment{
+		Reviewers:  []string{},
+		Fallback:   []string{},
+		Selections: []domain.ReviewerSelection{},
+		covered:    make(map[string]bool),
+		seed:       seed,
+	}
+}
+
+// add appends users picked with the strategy to the assignment
+func (a *reviewerAssignment) add(users []*domain.User, strategy domain.ReviewerStrategy, fallback bool) {
+	for _, user := range users {
+		a.Reviewers = append(a.Reviewers, user.UserID)
+		if fallback {
+			a.Fallback = append(a.Fallback, user.UserID)
+		}
+		a.Selections = append(a.Selections, domain.ReviewerSelection{
+			UserID:   user.UserID,
+			Strategy: strategy,
+			Seed:     a.seed,
+		})
+		for _, tag := range user.Tags {
+			a.covered[tag] = true
+		}
+	}
+}
+
+// uncoveredTags returns the required tags none of the picked reviewers has
+func (a *reviewerAssignment) uncoveredTags(required []string) []string {
+	uncovered := make([]string, 0, len(required))
+	for _, tag := range required {
+		if !a.covered[tag] {
+			uncovered = append(uncovered, tag)
+		}
+	}
+	return uncovered
+}
+
+// isFallback reports whether the reviewer was taken from another team
+func (a *reviewerAssignment) isFallback(userID string) bool {
+	return containsString(a.Fallback, userID)
+}
+
+// SetSeed reseeds the source of per-assignment seeds, making the sequence of assignments reproducible
+func (s *PullRequestService) SetSeed(seed int64) {
+	s.seedMu.Lock()
+	defer s.seedMu.Unlock()
+	s.seeds = rand.New(rand.NewSource(seed))
+}
+
+// newAssignmentRequest returns a request with a fresh seed and the random source it initializes
+func (s *PullRequestService) newAssignmentRequest() assignmentRequest {
+	s.seedMu.Lock()
+	seed := s.seeds.Int63()
+	s.seedMu.Unlock()
+
+	return assignmentRequest{Seed: seed, Rand: rand.New(rand.NewSource(seed))}
+}
+
+// pickReplacement selects a replacement for oldReviewer on the PR among active members of the
+// reviewer's team who are not in excludeIDs, following the team's fallback chain when nobody in the
+// team is available. Required tags of the PR not covered by the remaining reviewers are preferred.
+// It reports whether the replacement came from another team
+func (s *PullRequestService) pickReplacement(
+	pr *domain.PullRequest,
+	oldReviewer *domain.User,
+	excludeIDs []string,
+) (domain.ReviewerSelection, bool, error) {
+	settings, err := s.teamRepo.GetTeamSettings(oldReviewer.TeamName)
+	if err != nil {
+		return domain.ReviewerSelection{}, false, fmt.Errorf("failed to get team settings: %w", err)
+	}
+
+	remaining := newReviewerAssignment(0)
+	if len(pr.RequiredTags) > 0 {
+		for _, reviewerID := range pr.AssignedReviewers {
+			if reviewerID == oldReviewer.UserID {
+				continue
+			}
+			reviewer, err := s.userRepo.GetUser(reviewerID)
+			if err != nil {
+				return domain.ReviewerSelection{}, false, fmt.Errorf("failed to get reviewer %s: %w", reviewerID, err)
+			}
+			remaining.add([]*domain.User{reviewer}, "", false)
+		}
+	}
+
+	req := s.newAssignmentRequest()
+	req.AuthorID = pr.AuthorID
+	req.ExcludeIDs = excludeIDs
+	req.Count = 1
+	req.RequiredTags = remaining.uncoveredTags(pr.RequiredTags)
+	req.Strategy = strategyForPriority(pr.Priority)
+
+	assignment, err := s.assignReviewers(settings, req)
+	if err != nil {
+		return domain.ReviewerSelection{}, false, err
+	}
+	if len(assignment.Selections) == 0 {
+		return domain.ReviewerSelection{}, false, ErrNoCandidate
+	}
+
+	selection := assignment.Selections[0]
+	return selection, assignment.isFallback(selection.UserID), nil
+}
+
+// replaceReviewer reassigns oldReviewer's review on the open PR to a replacement picked by
+// planReplacement and updates the PR in place. The change is recorded in the assignment history of the PR
+func (s *PullRequestService) replaceReviewer(
+	pr *domain.PullRequest,
+	oldReviewer *domain.User,
+	excludeIDs []string,
+	change domain.AssignmentChange,
+) (string, error) {
+	selection, fromFallback, err := s.planReplacement(pr, oldReviewer, excludeIDs)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.prRepo.ReassignReviewer(pr.PullRequestID, oldReviewer.UserID, selection, fromFallback, change)
+	if err != nil {
+		return "", fmt.Errorf("failed to reassign reviewer: %w", err)
+	}
+
+	replaceAssigned(pr, oldReviewer.UserID, selection.UserID)
+	return selection.UserID, nil
+}
+
+// planReplacement picks the reviewer to take oldReviewer's review on the open PR over with
+// pickReplacement, skipping the author, current reviewers and excludeIDs, without assigning them
+func (s *PullRequestService) planReplacement(
+	pr *domain.PullRequest,
+	oldReviewer *domain.User,
+	excludeIDs []string,
+) (domain.ReviewerSelection, bool, error) {
+	exclude := append([]string{pr.AuthorID}, excludeIDs...)
+	exclude = append(exclude, pr.AssignedReviewers...)
+	return s.pickReplacement(pr, oldReviewer, exclude)
+}
+
+// replaceAssigned swaps a reviewer of the PR for another in place
+func replaceAssigned(pr *domain.PullRequest, oldUserID string, newUserID string) {
+	for i, reviewerID := range pr.AssignedReviewers {
+		if reviewerID == oldUserID {
+			pr.AssignedReviewers[i] = newUserID
+		}
+	}
+}
+
+// assignReviewers picks up to req.Count active reviewers from the team, skipping req.ExcludeIDs and
+// members at capacity. Slots left empty because of capacity are handled by the team's overflow
+// policy, and any slots still empty are filled from the team's fallback teams in order
+func (s *PullRequestService) assignReviewers(
+	settings *domain.TeamSettings,
+	req assignmentRequest,
+) (*reviewerAssignment, error) {
+	assignment := newReviewerAssignment(req.Seed)
+	if req.Count <= 0 {
+		return assignment, nil
+	}
+
+	reviewers, atCapacity, err := s.selectFromTeam(settings, req)
+	if err != nil {
+		return nil, err
+	}
+	assignment.add(reviewers, req.strategy(settings), false)
+
+	if len(assignment.Reviewers) < req.Count && atCapacity > 0 &&
+		settings.CapacityOverflow == domain.CapacityOverflowTeam && settings.OverflowTeam != "" {
+		if err := s.fillFromTeam(assignment, settings.OverflowTeam, req); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if len(assignment.Reviewers) >= req.Count {
+			break
+		}
+		if err := s.fillFromTeam(assignment, fallbackTeam, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(assignment.Reviewers) == 0 && atCapacity > 0 && settings.CapacityOverflow == domain.CapacityOverflowReject {
+		return nil, ErrCapacityExhausted
+	}
+
+	return assignment, nil
+}
+
+// fillFromTeam adds reviewers from another team to the assignment until it holds req.Count reviewers
+func (s *PullRequestService) fillFromTeam(
+	assignment *reviewerAssignment,
+	teamName string,
+	req assignmentRequest,
+) error {
+	settings, err := s.teamRepo.GetTeamSettings(teamName)
+	if err != nil {
+		return fmt.Errorf("failed to get settings of team %s: %w", teamName, err)
+	}
+
+	teamReq := req
+	teamReq.ExcludeIDs = append(append([]string{}, req.ExcludeIDs...), assignment.Reviewers...)
+	teamReq.Count = req.Count - len(assignment.Reviewers)
+	teamReq.RequiredTags = assignment.uncoveredTags(req.RequiredTags)
+
+	extra, _, err := s.selectFromTeam(settings, teamReq)
+	if err != nil {
+		return err
+	}
+
+	assignment.add(extra, req.strategy(settings), true)
+	return nil
+}
+
+// selectFromTeam picks up to req.Count reviewers among active members of the team who still have
+// spare capacity, preferring members covering req.RequiredTags. When the team enables affinity decay,
+// members who recently reviewed the same author are down-weighted.
+// It also returns how many otherwise eligible members were skipped as full
+func (s *PullRequestService) selectFromTeam(
+	settings *domain.TeamSettings,
+	req assignmentRequest,
+) ([]*domain.User, int, error) {
+	candidates, err := s.userRepo.GetActiveUsersByTeam(settings.TeamName, req.ExcludeIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get active users: %w", err)
+	}
+	if len(candidates) == 0 {
+		return []*domain.User{}, 0, nil
+	}
+
+	strategy := req.strategy(settings)
+	var openReviews map[string]int
+	if needsOpenReviews(strategy) || hasCapacityLimits(settings, candidates) {
+		openReviews, err = s.prRepo.GetOpenReviewCountsByTeam(settings.TeamName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get open review counts: %w", err)
+		}
+	}
+
+	available := make([]*domain.User, 0, len(candidates))
+	for _, candidate := range candidates {
+		if limit := reviewCapacity(candidate, settings); limit != nil && openReviews[candidate.UserID] >= *limit {
+			continue
+		}
+		available = append(available, candidate)
+	}
+
+	var recentPairings map[string]int
+	if settings.AffinityWindowDays > 0 && req.AuthorID != "" {
+		since := time.Now().AddDate(0, 0, -settings.AffinityWindowDays)
+		recentPairings, err = s.prRepo.GetRecentPairings(req.AuthorID, since)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get recent pairings: %w", err)
+		}
+	}
+
+	reviewerIDs := selectCoveringTags(s.selector(strategy), SelectionRequest{
+		TeamName:       settings.TeamName,
+		Candidates:     available,
+		Count:          req.Count,
+		OpenReviews:    openReviews,
+		Rand:           req.Rand,
+		RecentPairings: recentPairings,
+	}, req.RequiredTags)
+
+	return usersByID(available, reviewerIDs), len(candidates) - len(available), nil
+}
+
+// reviewCapacity returns the maximum number of open reviews for the user, or nil when unlimited
+func reviewCapacity(user *domain.User, settings *domain.TeamSettings) *int {
+	if user.MaxOpenReviews != nil {
+		return user.MaxOpenReviews
+	}
+	return settings.DefaultMaxOpenReviews
+}
+
+// hasCapacityLimits reports whether any of the candidates has a review capacity limit
+func hasCapacityLimits(settings *domain.TeamSettings, candidates []*domain.User) bool {
+	if settings.DefaultMaxOpenReviews != nil {
+		return true
+	}
+	for _, candidate := range candidates {
+		if candidate.MaxOpenReviews != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pickCodeOwners selects one reviewer for each distinct owner group of the changed files according
+// to the team's ownership file, up to count reviewers. Owners are picked regardless of capacity limits
+func (s *PullRequestService) pickCodeOwners(
+	settings *domain.TeamSettings,
+	authorID string,
+	changedFiles []string,
+	count int,
+	random *rand.Rand,
+) ([]*domain.User, error) {
+	picked := []*domain.User{}
+	if len(changedFiles) == 0 || count <= 0 {
+		return picked, nil
+	}
+
+	content, err := s.teamRepo.GetCodeOwners(settings.TeamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return picked, nil
+		}
+		return nil, fmt.Errorf("failed to get code owners: %w", err)
+	}
+
+	file, err := codeowners.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse code owners: %w", err)
+	}
+
+	seenGroups := make(map[string]bool)
+	for _, path := range changedFiles {
+		if len(picked) >= count {
+			break
+		}
+
+		owners := file.Owners(path)
+		groupKey := strings.Join(owners, " ")
+		if len(owners) == 0 || seenGroups[groupKey] {
+			continue
+		}
+		seenGroups[groupKey] = true
+
+		candidates, err := s.ownerCandidates(owners, authorID)
+		if err != nil {
+			return nil, err
+		}
+
+		pickedIDs := userIDs(picked)
+		available := make([]*domain.User, 0, len(candidates))
+		satisfied := false
+		for _, candidate := range candidates {
+			if containsString(pickedIDs, candidate.UserID) {
+				satisfied = true
+				break
+			}
+			available = append(available, candidate)
+		}
+		if satisfied {
+			continue
+		}
+
+		chosen := s.selector(settings.ReviewerStrategy).Select(SelectionRequest{
+			TeamName:   settings.TeamName,
+			Candidates: available,
+			Count:      1,
+			Rand:       random,
+		})
+		picked = append(picked, usersByID(available, chosen)...)
+	}
+
+	return picked, nil
+}
+
+// ownerCandidates expands code owners (users and "team/<name>" entries) into active users other than the author
+func (s *PullRequestService) ownerCandidates(owners []string, authorID string) ([]*domain.User, error) {
+	candidates := make([]*domain.User, 0, len(owners))
+	seen := make(map[string]bool)
+
+	for _, owner := range owners {
+		if teamName, ok := codeowners.TeamName(owner); ok {
+			members, err := s.userRepo.GetActiveUsersByTeam(teamName, []string{authorID})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get members of owner team %s: %w", teamName, err)
+			}
+			for _, member := range members {
+				if !seen[member.UserID] {
+					seen[member.UserID] = true
+					candidates = append(candidates, member)
+				}
+			}
+			continue
+		}
+
+		if owner == authorID || seen[owner] {
+			continue
+		}
+		user, err := s.userRepo.GetUser(owner)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get owner %s: %w", owner, err)
+		}
+		if user.IsActive && !user.IsAbsent {
+			seen[owner] = true
+			candidates = append(candidates, user)
+		}
+	}
+
+	return candidates, nil
+}
+
+// selector returns the selector implementing the strategy, defaulting to random
+func (s *PullRequestService) selector(strategy domain.ReviewerStrategy) ReviewerSelector {
+	if selector, ok := s.selectors[strategy]; ok {
+		return selector
+	}
+	return s.selectors[domain.ReviewerStrategyRandom]
+}
+
+// userIDs returns IDs of the users
+func userIDs(users []*domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.UserID)
+	}
+	return ids
+}
+
+// usersByID returns users with the given IDs in the order of ids
+func usersByID(users []*domain.User, ids []string) []*domain.User {
+	byID := make(map[string]*domain.User, len(users))
+	for _, user := range users {
+		byID[user.UserID] = user
+	}
+
+	result := make([]*domain.User, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := byID[id]; ok {
+			result = append(result, user)
+		}
+	}
+	return result
+}
+
+// containsString reports whether list holds value
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+)
+
+// ReviewerSelector picks reviewers out of a list of eligible candidates
+type ReviewerSelector interface {
+	// Select returns up to req.Count user IDs chosen from req.Candidates
+	Select(req SelectionRequest) []string
+}
+
+// SelectionRequest carries everything a selector may need to pick reviewers
+type SelectionRequest struct {
+	TeamName   string
+	Candidates []*domain.User
+	Count      int
+	// OpenReviews holds the number of OPEN PRs each candidate currently reviews
+	OpenReviews map[string]int
+	// Rand is the random source of the assignment. The seed alone reproduces picks of random and weighted
+	// only for the same candidates and OpenReviews; round_robin and least_loaded are not replayable from it
+	Rand *rand.Rand
+	// RecentPairings holds how many recent PRs of the same author each candidate reviewed;
+	// set only when the team enables affinity decay
+	RecentPairings map[string]int
+}
+
+// affinity returns the weight multiplier of a candidate: 1 / (recent pairings with the author + 1),
+// which is 1 when affinity decay is off
+func (r SelectionRequest) affinity(userID string) float64 {
+	return 1 / float64(r.RecentPairings[userID]+1)
+}
+
+// random returns the request's random source, or a time-seeded one when none is set
+func (r SelectionRequest) random() *rand.Rand {
+	if r.Rand != nil {
+		return r.Rand
+	}
+	return rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// needsOpenReviews reports whether the strategy relies on SelectionRequest.OpenReviews
+func needsOpenReviews(strategy domain.ReviewerStrategy) bool {
+	return strategy == domain.ReviewerStrategyLeastLoaded || strategy == domain.ReviewerStrategyWeighted
+}
+
+// NewReviewerSelectors returns the built-in selectors keyed by strategy
+func NewReviewerSelectors() map[domain.ReviewerStrategy]ReviewerSelector {
+	return map[domain.ReviewerStrategy]ReviewerSelector{
+		domain.ReviewerStrategyRandom:      &RandomSelector{},
+		domain.ReviewerStrategyRoundRobin:  NewRoundRobinSelector(),
+		domain.ReviewerStrategyLeastLoaded: &LeastLoadedSelector{},
+		domain.ReviewerStrategyWeighted:    &WeightedSelector{},
+	}
+}
+
+// selectCoveringTags picks up to req.Count reviewers so that every tag in requiredTags is covered
+// by at least one of them where possible. Candidates covering most of the still uncovered tags are
+// preferred and the selector breaks ties between them; remaining slots are filled by the selector
+func selectCoveringTags(selector ReviewerSelector, req SelectionRequest, requiredTags []string) []string {
+	uncovered := make(map[string]bool, len(requiredTags))
+	for _, tag := range requiredTags {
+		uncovered[tag] = true
+	}
+
+	remaining := make([]*domain.User, len(req.Candidates))
+	copy(remaining, req.Candidates)
+
+	picked := []string{}
+	for len(uncovered) > 0 && len(picked) < req.Count {
+		best := 0
+		var bestCandidates []*domain.User
+		for _, candidate := range remaining {
+			matches := 0
+			for _, tag := range candidate.Tags {
+				if uncovered[tag] {
+					matches++
+				}
+			}
+			if matches > best {
+				best = matches
+				bestCandidates = []*domain.User{candidate}
+			} else if matches == best && matches > 0 {
+				bestCandidates = append(bestCandidates, candidate)
+			}
+		}
+		if best == 0 {
+			break
+		}
+
+		pickReq := req
+		pickReq.Candidates = bestCandidates
+		pickReq.Count = 1
+		chosen := selector.Select(pickReq)
+		if len(chosen) == 0 {
+			break
+		}
+		picked = append(picked, chosen[0])
+
+		for i, candidate := range remaining {
+			if candidate.UserID == chosen[0] {
+				for _, tag := range candidate.Tags {
+					delete(uncovered, tag)
+				}
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+
+	restReq := req
+	restReq.Candidates = remaining
+	restReq.Count = req.Count - len(picked)
+	return append(picked, selector.Select(restReq)...)
+}
+
+// RandomSelector picks reviewers uniformly at random, down-weighting candidates
+// who recently reviewed the same author when affinity decay is on
+type RandomSelector struct{}
+
+func (s *RandomSelector) Select(req SelectionRequest) []string {
+	if len(req.RecentPairings) > 0 {
+		return weightedDraw(req.Candidates, req.Count, req.random(), func(candidate *domain.User) float64 {
+			return req.affinity(candidate.UserID)
+		})
+	}
+
+	shuffled := make([]*domain.User, len(req.Candidates))
+	copy(shuffled, req.Candidates)
+	req.random().Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	return firstUserIDs(shuffled, req.Count)
+}
+
+// RoundRobinSelector cycles through team members ordered by user_id,
+// continuing after the last reviewer it picked for the team. It ignores affinity decay and the seed:
+// the cursor lives in memory only, so its picks cannot be replayed and restart from the start of the team
+// after a restart
+type RoundRobinSelector struct {
+	mu     sync.Mutex
+	cursor map[string]string // team name -> last picked user_id
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{cursor: make(map[string]string)}
+}
+
+// clone returns a selector continuing from the same cursor without moving the cursor of s
+func (s *RoundRobinSelector) clone() *RoundRobinSelector {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cursor := make(map[string]string, len(s.cursor))
+	for teamName, userID := range s.cursor {
+		cursor[teamName] = userID
+	}
+	return &RoundRobinSelector{cursor: cursor}
+}
+
+func (s *RoundRobinSelector) Select(req SelectionRequest) []string {
+	if len(req.Candidates) == 0 || req.Count <= 0 {
+		return []string{}
+	}
+
+	sorted := make([]*domain.User, len(req.Candidates))
+	copy(sorted, req.Candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].UserID < sorted[j].UserID
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last := s.cursor[req.TeamName]
+	start := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i].UserID > last
+	})
+
+	count := min(req.Count, len(sorted))
+	reviewers := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		reviewers = append(reviewers, sorted[(start+i)%len(sorted)].UserID)
+	}
+
+	s.cursor[req.TeamName] = reviewers[len(reviewers)-1]
+	return reviewers
+}
+
+// LeastLoadedSelector picks reviewers with the fewest open reviews, breaking ties between
+// equally loaded candidates at random. Each recent pairing with the author counts as an extra open review.
+// Only the tie-break comes from the seed; the loads are not recorded, so its picks cannot be replayed
+// once reviews are opened or closed
+type LeastLoadedSelector struct{}
+
+func (s *LeastLoadedSelector) Select(req SelectionRequest) []string {
+	sorted := make([]*domain.User, len(req.Candidates))
+	copy(sorted, req.Candidates)
+	req.random().Shuffle(len(sorted), func(i, j int) {
+		sorted[i], sorted[j] = sorted[j], sorted[i]
+	})
+
+	load := func(userID string) int {
+		return req.OpenReviews[userID] + req.RecentPairings[userID]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return load(sorted[i].UserID) < load(sorted[j].UserID)
+	})
+
+	return firstUserIDs(sorted, req.Count)
+}
+
+// WeightedSelector picks reviewers at random with probability inversely
+// proportional to the number of open reviews they already have
+type WeightedSelector struct{}
+
+func (s *WeightedSelector) Select(req SelectionRequest) []string {
+	return weightedDraw(req.Candidates, req.Count, req.random(), func(candidate *domain.User) float64 {
+		return req.affinity(candidate.UserID) / float64(req.OpenReviews[candidate.UserID]+1)
+	})
+}
+
+// weightedDraw picks up to count candidates at random without replacement,
+// with probability proportional to their weight
+func weightedDraw(
+	candidates []*domain.User,
+	count int,
+	random *rand.Rand,
+	weight func(candidate *domain.User) float64,
+) []string {
+	remaining := make([]*domain.User, len(candidates))
+	copy(remaining, candidates)
+
+	count = max(min(count, len(remaining)), 0)
+	reviewers := make([]string, 0, count)
+	for len(reviewers) < count {
+		weights := make([]float64, len(remaining))
+		total := 0.0
+		for i, candidate := range remaining {
+			weights[i] = weight(candidate)
+			total += weights[i]
+		}
+
+		pick := len(remaining) - 1
+		target := random.Float64() * total
+		for i, w := range weights {
+			if target < w {
+				pick = i
+				break
+			}
+			target -= w
+		}
+
+		reviewers = append(reviewers, remaining[pick].UserID)
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+
+	return reviewers
+}
+
+// firstUserIDs returns user IDs of the first maxCount users
+func firstUserIDs(users []*domain.User, maxCount int) []string {
+	count := max(min(maxCount, len(users)), 0)
+
+	ids := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		ids = append(ids, users[i].UserID)
+	}
+	return ids
+}
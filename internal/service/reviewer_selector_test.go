@@ -0,0 +1,113 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testCandidates(ids ...string) []*domain.User {
+	users := make([]*domain.User, 0, len(ids))
+	for _, id := range ids {
+		users = append(users, &domain.User{UserID: id, TeamName: "backend", IsActive: true})
+	}
+	return users
+}
+
+func TestRoundRobinSelector_CyclesThroughTeam(t *testing.T) {
+	selector := NewRoundRobinSelector()
+	req := SelectionRequest{TeamName: "backend", Candidates: testCandidates("u3", "u1", "u2"), Count: 2}
+
+	assert.Equal(t, []string{"u1", "u2"}, selector.Select(req))
+	assert.Equal(t, []string{"u3", "u1"}, selector.Select(req))
+	assert.Equal(t, []string{"u2", "u3"}, selector.Select(req))
+}
+
+func TestSelectors_SeedDoesNotReplayStatefulStrategies(t *testing.T) {
+	// Round robin ignores the seed: the same seed picks differently as the cursor advances
+	roundRobin := NewRoundRobinSelector()
+	req := SelectionRequest{TeamName: "backend", Candidates: testCandidates("u1", "u2", "u3"), Count: 1}
+	req.Rand = rand.New(rand.NewSource(42))
+	first := roundRobin.Select(req)
+	req.Rand = rand.New(rand.NewSource(42))
+	assert.NotEqual(t, first, roundRobin.Select(req))
+
+	// Least loaded with the same seed picks differently once the loads change
+	leastLoaded := &LeastLoadedSelector{}
+	req = SelectionRequest{
+		TeamName:    "backend",
+		Candidates:  testCandidates("u1", "u2", "u3"),
+		Count:       1,
+		OpenReviews: map[string]int{"u1": 0, "u2": 3, "u3": 3},
+		Rand:        rand.New(rand.NewSource(42)),
+	}
+	assert.Equal(t, []string{"u1"}, leastLoaded.Select(req))
+	req.OpenReviews = map[string]int{"u1": 3, "u2": 0, "u3": 3}
+	req.Rand = rand.New(rand.NewSource(42))
+	assert.Equal(t, []string{"u2"}, leastLoaded.Select(req))
+
+	// Random is fully determined by the seed and the candidates
+	random := &RandomSelector{}
+	req = SelectionRequest{TeamName: "backend", Candidates: testCandidates("u1", "u2", "u3"), Count: 2}
+	req.Rand = rand.New(rand.NewSource(42))
+	first = random.Select(req)
+	req.Rand = rand.New(rand.NewSource(42))
+	assert.Equal(t, first, random.Select(req))
+}
+
+func TestSelectors_RespectCount(t *testing.T) {
+	candidates := testCandidates("u1", "u2", "u3")
+
+	for strategy, selector := range NewReviewerSelectors() {
+		req := SelectionRequest{TeamName: "backend", Candidates: candidates, Count: 2}
+		reviewers := selector.Select(req)
+		assert.Len(t, reviewers, 2, "strategy %s", strategy)
+		assert.NotEqual(t, reviewers[0], reviewers[1], "strategy %s", strategy)
+
+		req.Count = 5
+		assert.Len(t, selector.Select(req), 3, "strategy %s", strategy)
+
+		req.Candidates = nil
+		assert.Empty(t, selector.Select(req), "strategy %s", strategy)
+	}
+}
+
+func TestLeastLoadedSelector_BreaksTiesRandomly(t *testing.T) {
+	selector := &LeastLoadedSelector{}
+	req := SelectionRequest{
+		TeamName:    "backend",
+		Candidates:  testCandidates("u1", "u2", "u3"),
+		Count:       1,
+		OpenReviews: map[string]int{"u1": 4, "u2": 1, "u3": 1},
+	}
+
+	picked := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		picked[selector.Select(req)[0]]++
+	}
+
+	assert.Zero(t, picked["u1"])
+	assert.Positive(t, picked["u2"])
+	assert.Positive(t, picked["u3"])
+}
+
+func TestRandomSelector_DownWeightsRecentPairings(t *testing.T) {
+	selector := &RandomSelector{}
+	req := SelectionRequest{
+		TeamName:       "backend",
+		Candidates:     testCandidates("u1", "u2"),
+		Count:          1,
+		Rand:           rand.New(rand.NewSource(1)),
+		RecentPairings: map[string]int{"u1": 9},
+	}
+
+	picked := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		picked[selector.Select(req)[0]]++
+	}
+
+	assert.Greater(t, picked["u2"], 3*picked["u1"])
+}
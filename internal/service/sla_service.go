@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"avito-tech-internship/internal/domain"
+	"avito-tech-internship/internal/repository"
+)
+
+// SLAService tracks review assignments that exceed the review SLA of the author's team
+type SLAService struct {
+	prRepo    repository.PullRequestRepository
+	teamRepo  repository.TeamRepository
+	prService *PullRequestService
+}
+
+func NewSLAService(
+	prRepo repository.PullRequestRepository,
+	teamRepo repository.TeamRepository,
+	prService *PullRequestService,
+) *SLAService {
+	return &SLAService{
+		prRepo:    prRepo,
+		teamRepo:  teamRepo,
+		prService: prService,
+	}
+}
+
+// EscalateOverdueReviews marks assignments that exceeded the SLA as overdue and reassigns every overdue
+// assignment still awaiting a verdict when the author's team has sla_reassign set. Assignments whose
+// reassignment failed on an earlier call stay overdue with their reviewer and are retried, e.g. once
+// a candidate frees up. It returns how many assignments became overdue and how many were reassigned
+func (s *SLAService) EscalateOverdueReviews() (int, int, error) {
+	marked, err := s.prRepo.MarkOverdueAssignments(time.Now())
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to mark overdue assignments: %w", err)
+	}
+
+	// A reassigned review starts anew with the replacement, so only failed escalations are left from before
+	overdue, err := s.prRepo.GetOverdueAssignments("")
+	if err != nil {
+		return len(marked), 0, fmt.Errorf("failed to get overdue assignments: %w", err)
+	}
+
+	settingsByTeam := make(map[string]*domain.TeamSettings)
+	reassigned := 0
+	var errs []error
+	for _, assignment := range overdue {
+		settings, ok := settingsByTeam[assignment.TeamName]
+		if !ok {
+			settings, err = s.teamRepo.GetTeamSettings(assignment.TeamName)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("team %s: failed to get team settings: %w", assignment.TeamName, err))
+				continue
+			}
+			settingsByTeam[assignment.TeamName] = settings
+		}
+		if !settings.SLAReassign {
+			continue
+		}
+
+		change := domain.AssignmentChange{Actor: SystemActor, Reason: "review SLA exceeded"}
+		_, _, err := s.prService.ReassignReviewer(assignment.PullRequestID, assignment.UserID, change)
+		switch {
+		case err == nil:
+			reassigned++
+		case errors.Is(err, ErrNoCandidate), errors.Is(err, ErrCapacityExhausted):
+			// Nobody can take the review over yet, it stays overdue with the current reviewer until the next call
+		default:
+			errs = append(errs, fmt.Errorf("PR %s reviewer %s: %w", assignment.PullRequestID, assignment.UserID, err))
+		}
+	}
+
+	return len(marked), reassigned, errors.Join(errs...)
+}
+
+// GetOverdueAssignments returns overdue assignments still awaiting a verdict, optionally limited
+// to PRs of the given team's authors
+func (s *SLAService) GetOverdueAssignments(teamName string) ([]*domain.OverdueAssignment, error) {
+	if teamName != "" {
+		exists, err := s.teamRepo.TeamExists(teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team existence: %w", err)
+		}
+		if !exists {
+			return nil, ErrTeamNotFound
+		}
+	}
+
+	assignments, err := s.prRepo.GetOverdueAssignments(teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue assignments: %w", err)
+	}
+	return assignments, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSLAService_EscalateOverdueReviews(t *testing.T) {
+	mockPRRepo := new(MockPullRequestRepository)
+	mockUserRepo := new(MockUserRepository)
+	mockTeamRepo := new(MockTeamRepository)
+
+	prService := NewPullRequestService(nil, mockPRRepo, mockUserRepo, mockTeamRepo)
+	service := NewSLAService(mockPRRepo, mockTeamRepo, prService)
+
+	mockPRRepo.On("MarkOverdueAssignments", mock.AnythingOfType("time.Time")).Return([]*domain.OverdueAssignment{
+		{PullRequestID: "pr-1", AuthorID: "u1", UserID: "u2", TeamName: "backend"},
+		{PullRequestID: "pr-2", AuthorID: "m1", UserID: "m2", TeamName: "mobile"},
+	}, nil)
+	// pr-0 became overdue on an earlier check, when nobody could take it over
+	mockPRRepo.On("GetOverdueAssignments", "").Return([]*domain.OverdueAssignment{
+		{PullRequestID: "pr-0", AuthorID: "u1", UserID: "u2", TeamName: "backend"},
+		{PullRequestID: "pr-1", AuthorID: "u1", UserID: "u2", TeamName: "backend"},
+		{PullRequestID: "pr-2", AuthorID: "m1", UserID: "m2", TeamName: "mobile"},
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "backend").Return(&domain.TeamSettings{
+		TeamName:         "backend",
+		ReviewerStrategy: domain.ReviewerStrategyRandom,
+		ReviewSLAHours:   24,
+		SLAReassign:      true,
+	}, nil)
+	mockTeamRepo.On("GetTeamSettings", "mobile").Return(&domain.TeamSettings{
+		TeamName:       "mobile",
+		ReviewSLAHours: 48,
+	}, nil)
+
+	for _, prID := range []string{"pr-0", "pr-1"} {
+		mockPRRepo.On("GetPR", prID).Return(&domain.PullRequest{
+			PullRequestID:     prID,
+			AuthorID:          "u1",
+			Status:            domain.PRStatusOpen,
+			AssignedReviewers: []string{"u2"},
+		}, nil)
+	}
+	mockUserRepo.On("GetUser", "u2").Return(&domain.User{UserID: "u2", TeamName: "backend"}, nil)
+	mockUserRepo.On("GetActiveUsersByTeam", "backend", []string{"u1", "u2"}).Return([]*domain.User{
+		{UserID: "u3", Username: "Charlie", TeamName: "backend", IsActive: true},
+	}, nil)
+	change := domain.AssignmentChange{Actor: SystemActor, Reason: "review SLA exceeded"}
+	mockPRRepo.On("ReassignReviewer", "pr-0", "u2", "u3", false, change).Return(nil)
+	mockPRRepo.On("ReassignReviewer", "pr-1", "u2", "u3", false, change).Return(nil)
+
+	overdue, reassigned, err := service.EscalateOverdueReviews()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, overdue)
+	assert.Equal(t, 2, reassigned)
+
+	mockPRRepo.AssertExpectations(t)
+	mockPRRepo.AssertNotCalled(t, "GetPR", "pr-2")
+}
+
+func TestSLAService_GetOverdueAssignments_UnknownTeam(t *testing.T) {
+	mockTeamRepo := new(MockTeamRepository)
+	service := NewSLAService(new(MockPullRequestRepository), mockTeamRepo, nil)
+
+	mockTeamRepo.On("TeamExists", "ghosts").Return(false, nil)
+
+	_, err := service.GetOverdueAssignments("ghosts")
+	assert.ErrorIs(t, err, ErrTeamNotFound)
+}
@@ -6,11 +6,15 @@ import (
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
+	"avito-tech-internship/pkg/codeowners"
 )
 
 var (
-	ErrTeamExists   = errors.New("team already exists")
-	ErrTeamNotFound = errors.New("team not found")
+	ErrTeamExists      = errors.New("team already exists")
+	ErrTeamNotFound    = errors.New("team not found")
+	ErrInvalidStrategy = errors.New("unknown reviewer strategy")
+	ErrInvalidSettings = errors.New("invalid team settings")
+	ErrInvalidOwners   = errors.New("invalid code owners file")
 )
 
 type TeamService struct {
@@ -21,8 +25,27 @@ func NewTeamService(teamRepo repository.TeamRepository) *TeamService {
 	return &TeamService{teamRepo: teamRepo}
 }
 
+// withRepositories returns a copy of the service working on the given repositories, e.g. ones bound to a transaction
+func (s *TeamService) withRepositories(repos repository.Repositories) *TeamService {
+	return &TeamService{teamRepo: repos.Teams}
+}
+
 // CreateTeam creates a new team with members (creates/updates users)
 func (s *TeamService) CreateTeam(team *domain.Team) error {
+	if team.ReviewerStrategy != "" && !team.ReviewerStrategy.IsValid() {
+		return ErrInvalidStrategy
+	}
+	for i, member := range team.Members {
+		if member.MaxOpenReviews != nil && *member.MaxOpenReviews < 0 {
+			return ErrInvalidCapacity
+		}
+		tags, err := normalizeTags(member.Tags)
+		if err != nil {
+			return err
+		}
+		team.Members[i].Tags = tags
+	}
+
 	exists, err := s.teamRepo.TeamExists(team.TeamName)
 	if err != nil {
 		return fmt.Errorf("failed to check team existence: %w", err)
@@ -49,3 +72,141 @@ func (s *TeamService) GetTeam(teamName string) (*domain.Team, error) {
 	}
 	return team, nil
 }
+
+// GetTeamSettings retrieves reviewer assignment settings of a team
+func (s *TeamService) GetTeamSettings(teamName string) (*domain.TeamSettings, error) {
+	settings, err := s.teamRepo.GetTeamSettings(teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateTeamSettings validates and stores reviewer assignment settings of a team
+func (s *TeamService) UpdateTeamSettings(settings *domain.TeamSettings) error {
+	if err := s.validateSettings(settings); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.UpdateTeamSettings(settings); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to update team settings: %w", err)
+	}
+	return nil
+}
+
+// validateSettings checks settings consistency, filling in defaults for empty values
+func (s *TeamService) validateSettings(settings *domain.TeamSettings) error {
+	if settings.ReviewerStrategy == "" {
+		settings.ReviewerStrategy = domain.ReviewerStrategyRandom
+	}
+	if !settings.ReviewerStrategy.IsValid() {
+		return ErrInvalidStrategy
+	}
+
+	if settings.DefaultMaxOpenReviews != nil && *settings.DefaultMaxOpenReviews < 0 {
+		return ErrInvalidCapacity
+	}
+
+	if settings.CapacityOverflow == "" {
+		settings.CapacityOverflow = domain.CapacityOverflowAssignFewer
+	}
+	if !settings.CapacityOverflow.IsValid() {
+		return ErrInvalidSettings
+	}
+	if settings.CapacityOverflow == domain.CapacityOverflowTeam && settings.OverflowTeam == "" {
+		return ErrInvalidSettings
+	}
+
+	if settings.MinReviewerCount < 0 ||
+		settings.MinReviewerCount > settings.ReviewerCount ||
+		settings.ReviewerCount > settings.MaxReviewerCount {
+		return ErrInvalidSettings
+	}
+
+	if settings.AffinityWindowDays < 0 || settings.RequiredApprovals < 0 || settings.ReviewSLAHours < 0 ||
+		settings.LargePRLines < 0 {
+		return ErrInvalidSettings
+	}
+	// A PR never has more reviewers than MaxReviewerCount, so more approvals could not be collected
+	if settings.RequiredApprovals > settings.MaxReviewerCount {
+		return ErrInvalidSettings
+	}
+
+	otherTeams := make([]string, 0, len(settings.FallbackTeams)+1)
+	if settings.OverflowTeam != "" {
+		otherTeams = append(otherTeams, settings.OverflowTeam)
+	}
+
+	seen := make(map[string]bool, len(settings.FallbackTeams))
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if seen[fallbackTeam] {
+			return ErrInvalidSettings
+		}
+		seen[fallbackTeam] = true
+		otherTeams = append(otherTeams, fallbackTeam)
+	}
+
+	for _, teamName := range otherTeams {
+		if teamName == "" || teamName == settings.TeamName {
+			return ErrInvalidSettings
+		}
+		exists, err := s.teamRepo.TeamExists(teamName)
+		if err != nil {
+			return fmt.Errorf("failed to check team %s existence: %w", teamName, err)
+		}
+		if !exists {
+			return ErrTeamNotFound
+		}
+	}
+
+	return nil
+}
+
+// GetCodeOwners retrieves the ownership file of a team (empty if the team has none)
+func (s *TeamService) GetCodeOwners(teamName string) (string, error) {
+	if err := s.ensureTeamExists(teamName); err != nil {
+		return "", err
+	}
+
+	content, err := s.teamRepo.GetCodeOwners(teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get code owners: %w", err)
+	}
+	return content, nil
+}
+
+// SetCodeOwners validates and stores the CODEOWNERS-style ownership file of a team
+func (s *TeamService) SetCodeOwners(teamName string, content string) error {
+	if _, err := codeowners.Parse(content); err != nil {
+		return ErrInvalidOwners
+	}
+
+	if err := s.ensureTeamExists(teamName); err != nil {
+		return err
+	}
+
+	if err := s.teamRepo.SetCodeOwners(teamName, content); err != nil {
+		return fmt.Errorf("failed to set code owners: %w", err)
+	}
+	return nil
+}
+
+func (s *TeamService) ensureTeamExists(teamName string) error {
+	exists, err := s.teamRepo.TeamExists(teamName)
+	if err != nil {
+		return fmt.Errorf("failed to check team existence: %w", err)
+	}
+	if !exists {
+		return ErrTeamNotFound
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"testing"
+
+	"avito-tech-internship/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamService_UpdateTeamSettings_RequiredApprovals(t *testing.T) {
+	tests := []struct {
+		name              string
+		requiredApprovals int
+		wantErr           error
+	}{
+		{name: "up to max reviewer count", requiredApprovals: 3},
+		{name: "negative", requiredApprovals: -1, wantErr: ErrInvalidSettings},
+		{name: "above max reviewer count", requiredApprovals: 4, wantErr: ErrInvalidSettings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTeamRepo := new(MockTeamRepository)
+			service := NewTeamService(mockTeamRepo)
+
+			settings := &domain.TeamSettings{
+				TeamName:          "backend",
+				ReviewerCount:     2,
+				MinReviewerCount:  1,
+				MaxReviewerCount:  3,
+				RequiredApprovals: tt.requiredApprovals,
+			}
+			if tt.wantErr == nil {
+				mockTeamRepo.On("UpdateTeamSettings", settings).Return(nil)
+			}
+
+			err := service.UpdateTeamSettings(settings)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockTeamRepo.AssertNotCalled(t, "UpdateTeamSettings", settings)
+				return
+			}
+			assert.NoError(t, err)
+			mockTeamRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -3,15 +3,21 @@ package service
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/repository"
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrInvalidCapacity = errors.New("max_open_reviews must not be negative")
+	ErrInvalidTag      = errors.New("tags must be non-empty and at most 64 characters")
 )
 
+// maxTagLength matches the column size of user_tags.tag
+const maxTagLength = 64
+
 type UserService struct {
 	userRepo repository.UserRepository
 }
@@ -32,6 +38,68 @@ func (s *UserService) SetIsActive(userID string, isActive bool) (*domain.User, e
 	return user, nil
 }
 
+// SetMaxOpenReviews updates the review capacity of a user (nil falls back to the team default)
+func (s *UserService) SetMaxOpenReviews(userID string, maxOpenReviews *int) (*domain.User, error) {
+	if maxOpenReviews != nil && *maxOpenReviews < 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	user, err := s.userRepo.SetMaxOpenReviews(userID, maxOpenReviews)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to set user capacity: %w", err)
+	}
+	return user, nil
+}
+
+// GetUserTags returns the tags of a user
+func (s *UserService) GetUserTags(userID string) ([]string, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Tags == nil {
+		return []string{}, nil
+	}
+	return user.Tags, nil
+}
+
+// AddUserTags adds tags to a user
+func (s *UserService) AddUserTags(userID string, tags []string) (*domain.User, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.AddUserTags(userID, tags)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to add user tags: %w", err)
+	}
+	return user, nil
+}
+
+// RemoveUserTags removes tags from a user
+func (s *UserService) RemoveUserTags(userID string, tags []string) (*domain.User, error) {
+	tags, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.RemoveUserTags(userID, tags)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to remove user tags: %w", err)
+	}
+	return user, nil
+}
+
 // GetUser retrieves a user by ID
 func (s *UserService) GetUser(userID string) (*domain.User, error) {
 	user, err := s.userRepo.GetUser(userID)
@@ -52,3 +120,24 @@ func (s *UserService) GetActiveUsersByTeam(teamName string, excludeUserIDs []str
 	}
 	return users, nil
 }
+
+// normalizeTags lowercases, trims and deduplicates tags, keeping their order
+func normalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || len(tag) > maxTagLength {
+			return nil, ErrInvalidTag
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized, nil
+}
@@ -0,0 +1,129 @@
+// Package codeowners parses CODEOWNERS-style ownership files and matches paths against them
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// TeamPrefix marks an owner that refers to a whole team, e.g. "@team/backend"
+const TeamPrefix = "team/"
+
+// Rule maps a path pattern to its owners
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// File is a parsed ownership file
+type File struct {
+	Rules []Rule
+}
+
+// Parse parses CODEOWNERS content. Each non-empty line that is not a comment consists of a
+// glob pattern followed by owners separated by whitespace. Owners may be prefixed with "@"
+func Parse(content string) (*File, error) {
+	file := &File{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = strings.TrimSpace(line[:idx])
+		}
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		rule := Rule{Pattern: fields[0], Owners: make([]string, 0, len(fields)-1)}
+		for _, owner := range fields[1:] {
+			owner = strings.TrimPrefix(owner, "@")
+			if owner == "" || owner == TeamPrefix {
+				return nil, fmt.Errorf("line %d: empty owner", lineNum)
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+
+		re, err := compilePattern(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		rule.re = re
+
+		file.Rules = append(file.Rules, rule)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ownership file: %w", err)
+	}
+
+	return file, nil
+}
+
+// Owners returns owners of the path. As in CODEOWNERS, the last matching rule wins
+func (f *File) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].re.MatchString(path) {
+			return f.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// TeamName returns the team name if the owner refers to a team
+func TeamName(owner string) (string, bool) {
+	if strings.HasPrefix(owner, TeamPrefix) {
+		return strings.TrimPrefix(owner, TeamPrefix), true
+	}
+	return "", false
+}
+
+// compilePattern converts a CODEOWNERS glob into a regular expression.
+// Patterns containing a slash (other than a trailing one) are anchored to the root,
+// other patterns match at any depth. A match on a directory covers everything below it
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern")
+	}
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("(?:/.*)?$")
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,46 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_LastMatchWins(t *testing.T) {
+	file, err := Parse(`
+# Default owners
+*              @u1
+*.sql          @u2 @team/dba
+/internal/api/ @u3
+docs/**/*.md   @u4
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"u1"}, file.Owners("cmd/server/main.go"))
+	assert.Equal(t, []string{"u2", "team/dba"}, file.Owners("internal/migrations/init.up.sql"))
+	assert.Equal(t, []string{"u3"}, file.Owners("internal/api/handler.go"))
+	assert.Equal(t, []string{"u1"}, file.Owners("pkg/internal/api/handler.go"))
+	assert.Equal(t, []string{"u4"}, file.Owners("docs/guides/setup/intro.md"))
+}
+
+func TestParse_NoMatch(t *testing.T) {
+	file, err := Parse("/frontend/ @u1")
+	require.NoError(t, err)
+
+	assert.Nil(t, file.Owners("backend/main.go"))
+}
+
+func TestParse_InvalidOwner(t *testing.T) {
+	_, err := Parse("*.go @")
+	assert.Error(t, err)
+}
+
+func TestTeamName(t *testing.T) {
+	team, ok := TeamName("team/backend")
+	assert.True(t, ok)
+	assert.Equal(t, "backend", team)
+
+	_, ok = TeamName("u1")
+	assert.False(t, ok)
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"testing"
 
+	"avito-tech-internship/internal/config"
 	"avito-tech-internship/internal/domain"
 	"avito-tech-internship/internal/migrations"
 	"avito-tech-internship/internal/router"
@@ -53,7 +54,7 @@ func cleanupTestDB(t *testing.T, db *sql.DB) {
 	if db == nil {
 		return
 	}
-	tables := []string{"pr_reviewers", "pull_requests", "users", "teams", "schema_migrations"}
+	tables := []string{"assignment_events", "pr_reviewers", "pull_requests", "users", "teams", "schema_migrations"}
 	for _, table := range tables {
 		_, err := db.Exec("TRUNCATE TABLE " + table + " CASCADE")
 		if err != nil {
@@ -77,7 +78,7 @@ func TestCreateTeamE2E(t *testing.T) {
 	defer db.Close()
 	defer cleanupTestDB(t, db)
 
-	router := router.SetupRouter(db)
+	router := router.SetupRouter(router.NewServices(db, config.SelectionConfig{}), config.AdminConfig{})
 
 	// Create team via API
 	team := domain.Team{